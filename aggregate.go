@@ -1,6 +1,7 @@
 package gormx
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
@@ -14,14 +15,19 @@ type AggregateResult struct {
 
 // Sum calculates the sum of a numeric field
 func Sum[T any](field string, where *Where) (float64, error) {
+	return SumCtx[T](context.Background(), field, where)
+}
+
+// SumCtx calculates the sum of a numeric field with context.
+func SumCtx[T any](ctx context.Context, field string, where *Where) (float64, error) {
 	var result struct {
 		Sum float64 `gorm:"column:sum"`
 	}
 
-	query := GetDB().Model(new(T))
+	query := GetDBWithContext(ctx).Model(new(T))
 
 	if where != nil {
-		whereClause, whereValues, err := where.Build()
+		whereClause, whereValues, err := where.BuildFor(query)
 		if err != nil {
 			return 0, err
 		}
@@ -40,14 +46,19 @@ func Sum[T any](field string, where *Where) (float64, error) {
 
 // Avg calculates the average of a numeric field
 func Avg[T any](field string, where *Where) (float64, error) {
+	return AvgCtx[T](context.Background(), field, where)
+}
+
+// AvgCtx calculates the average of a numeric field with context.
+func AvgCtx[T any](ctx context.Context, field string, where *Where) (float64, error) {
 	var result struct {
 		Avg float64 `gorm:"column:avg"`
 	}
 
-	query := GetDB().Model(new(T))
+	query := GetDBWithContext(ctx).Model(new(T))
 
 	if where != nil {
-		whereClause, whereValues, err := where.Build()
+		whereClause, whereValues, err := where.BuildFor(query)
 		if err != nil {
 			return 0, err
 		}
@@ -66,12 +77,17 @@ func Avg[T any](field string, where *Where) (float64, error) {
 
 // Min finds the minimum value of a field
 func Min[T any](field string, where *Where) (interface{}, error) {
+	return MinCtx[T](context.Background(), field, where)
+}
+
+// MinCtx finds the minimum value of a field with context.
+func MinCtx[T any](ctx context.Context, field string, where *Where) (interface{}, error) {
 	var result map[string]interface{}
 
-	query := GetDB().Model(new(T))
+	query := GetDBWithContext(ctx).Model(new(T))
 
 	if where != nil {
-		whereClause, whereValues, err := where.Build()
+		whereClause, whereValues, err := where.BuildFor(query)
 		if err != nil {
 			return nil, err
 		}
@@ -90,12 +106,17 @@ func Min[T any](field string, where *Where) (interface{}, error) {
 
 // Max finds the maximum value of a field
 func Max[T any](field string, where *Where) (interface{}, error) {
+	return MaxCtx[T](context.Background(), field, where)
+}
+
+// MaxCtx finds the maximum value of a field with context.
+func MaxCtx[T any](ctx context.Context, field string, where *Where) (interface{}, error) {
 	var result map[string]interface{}
 
-	query := GetDB().Model(new(T))
+	query := GetDBWithContext(ctx).Model(new(T))
 
 	if where != nil {
-		whereClause, whereValues, err := where.Build()
+		whereClause, whereValues, err := where.BuildFor(query)
 		if err != nil {
 			return nil, err
 		}
@@ -114,14 +135,19 @@ func Max[T any](field string, where *Where) (interface{}, error) {
 
 // CountDistinct counts distinct values of a field
 func CountDistinct[T any](field string, where *Where) (int64, error) {
+	return CountDistinctCtx[T](context.Background(), field, where)
+}
+
+// CountDistinctCtx counts distinct values of a field with context.
+func CountDistinctCtx[T any](ctx context.Context, field string, where *Where) (int64, error) {
 	var result struct {
 		Count int64 `gorm:"column:count"`
 	}
 
-	query := GetDB().Model(new(T))
+	query := GetDBWithContext(ctx).Model(new(T))
 
 	if where != nil {
-		whereClause, whereValues, err := where.Build()
+		whereClause, whereValues, err := where.BuildFor(query)
 		if err != nil {
 			return 0, err
 		}
@@ -150,6 +176,11 @@ type GroupByResult struct {
 
 // GroupBy performs group by operations with optional aggregations
 func GroupBy[T any](fields []string, where *Where, aggregates []string) ([]GroupByResult, error) {
+	return GroupByCtx[T](context.Background(), fields, where, aggregates)
+}
+
+// GroupByCtx performs group by operations with optional aggregations with context.
+func GroupByCtx[T any](ctx context.Context, fields []string, where *Where, aggregates []string) ([]GroupByResult, error) {
 	if len(fields) == 0 {
 		return nil, fmt.Errorf("group by fields cannot be empty")
 	}
@@ -162,10 +193,10 @@ func GroupBy[T any](fields []string, where *Where, aggregates []string) ([]Group
 		selectClause += ", " + strings.Join(aggregates, ", ")
 	}
 
-	query := GetDB().Model(new(T))
+	query := GetDBWithContext(ctx).Model(new(T))
 
 	if where != nil {
-		whereClause, whereValues, err := where.Build()
+		whereClause, whereValues, err := where.BuildFor(query)
 		if err != nil {
 			return nil, err
 		}
@@ -246,6 +277,11 @@ func GroupBy[T any](fields []string, where *Where, aggregates []string) ([]Group
 
 // Aggregate performs multiple aggregate operations in a single query
 func Aggregate[T any](field string, where *Where, operations []string) (map[string]interface{}, error) {
+	return AggregateCtx[T](context.Background(), field, where, operations)
+}
+
+// AggregateCtx performs multiple aggregate operations in a single query with context.
+func AggregateCtx[T any](ctx context.Context, field string, where *Where, operations []string) (map[string]interface{}, error) {
 	if len(operations) == 0 {
 		return nil, fmt.Errorf("aggregate operations cannot be empty")
 	}
@@ -271,10 +307,10 @@ func Aggregate[T any](field string, where *Where, operations []string) (map[stri
 		}
 	}
 
-	query := GetDB().Model(new(T))
+	query := GetDBWithContext(ctx).Model(new(T))
 
 	if where != nil {
-		whereClause, whereValues, err := where.Build()
+		whereClause, whereValues, err := where.BuildFor(query)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,160 @@
+package gormx
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+const (
+	// CreatorField is the field of the user who creates the record, see ModelImpl.
+	CreatorField = "Creator"
+	// ModifierField is the field of the user who modifies the record last, see ModelImpl.
+	ModifierField = "Modifier"
+)
+
+// UserIDExtractor extracts the current user ID from the context, 0 if there is none.
+type UserIDExtractor func(ctx context.Context) uint
+
+type userIDContextKey struct{}
+
+var userIDExtractor UserIDExtractor = defaultUserIDExtractor
+var userIDExtractorLock sync.RWMutex
+
+// WithUserID returns a copy of ctx carrying the current user ID,
+// which populates the Creator and Modifier fields of the records written with it.
+func WithUserID(ctx context.Context, id uint) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithValue(ctx, userIDContextKey{}, id)
+}
+
+func defaultUserIDExtractor(ctx context.Context) uint {
+	id, _ := ctx.Value(userIDContextKey{}).(uint)
+	return id
+}
+
+// SetUserIDExtractor sets the extractor of the current user ID, e.g. from the claims
+// of the authentication middleware, default is the user ID of WithUserID.
+func SetUserIDExtractor(extractor UserIDExtractor) {
+	userIDExtractorLock.Lock()
+	defer userIDExtractorLock.Unlock()
+
+	if extractor == nil {
+		extractor = defaultUserIDExtractor
+	}
+
+	userIDExtractor = extractor
+}
+
+// UserIDFromContext returns the current user ID of ctx by the extractor, 0 if there is none.
+func UserIDFromContext(ctx context.Context) uint {
+	if ctx == nil {
+		return 0
+	}
+
+	userIDExtractorLock.RLock()
+	extractor := userIDExtractor
+	userIDExtractorLock.RUnlock()
+
+	return extractor(ctx)
+}
+
+// userIDOf returns the ID of the user of the zoox context, which is an ID,
+// or a struct with an ID field, e.g. a model embedding ModelImpl.
+func userIDOf(user any) uint {
+	rv := reflect.ValueOf(user)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return 0
+		}
+
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() == reflect.Struct {
+		rv = rv.FieldByName("ID")
+	}
+
+	switch rv.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return uint(rv.Uint())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if rv.Int() > 0 {
+			return uint(rv.Int())
+		}
+	}
+
+	return 0
+}
+
+// setAuditFieldsOnCreate sets the creator and modifier of the created records if not set.
+func setAuditFieldsOnCreate(db *gorm.DB) {
+	stmt := db.Statement
+	if stmt.Schema == nil {
+		return
+	}
+
+	creator := stmt.Schema.LookUpField(CreatorField)
+	modifier := stmt.Schema.LookUpField(ModifierField)
+	if creator == nil && modifier == nil {
+		return
+	}
+
+	userID := UserIDFromContext(stmt.Context)
+	if userID == 0 {
+		return
+	}
+
+	set := func(rv reflect.Value) {
+		for _, field := range []*schema.Field{creator, modifier} {
+			if field == nil {
+				continue
+			}
+
+			if _, isZero := field.ValueOf(stmt.Context, rv); isZero {
+				db.AddError(field.Set(stmt.Context, rv, userID))
+			}
+		}
+	}
+
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			set(reflect.Indirect(stmt.ReflectValue.Index(i)))
+		}
+	case reflect.Struct:
+		set(stmt.ReflectValue)
+	}
+}
+
+// setAuditFieldsOnUpdate sets the modifier of the updated records.
+func setAuditFieldsOnUpdate(db *gorm.DB) {
+	stmt := db.Statement
+	// UpdateColumns skips the hooks and the update time, so does the modifier
+	if stmt.Schema == nil || stmt.SkipHooks {
+		return
+	}
+
+	modifier := stmt.Schema.LookUpField(ModifierField)
+	if modifier == nil {
+		return
+	}
+
+	userID := UserIDFromContext(stmt.Context)
+	if userID == 0 {
+		return
+	}
+
+	switch stmt.Dest.(type) {
+	case map[string]interface{}, []map[string]interface{}:
+		stmt.SetColumn(modifier.DBName, userID, true)
+	default:
+		stmt.SetColumn(modifier.Name, userID, true)
+	}
+}
@@ -0,0 +1,60 @@
+package gormx
+
+import (
+	"context"
+	"testing"
+)
+
+type TestAuditedItem struct {
+	ID       uint   `gorm:"primarykey"`
+	Name     string `gorm:"column:name"`
+	Creator  uint   `gorm:"column:creator"`
+	Modifier uint   `gorm:"column:modifier"`
+}
+
+func TestAuditFields(t *testing.T) {
+	ctx := openTestDB(t, "test_audit_fields", &TestAuditedItem{})
+
+	one, err := CreateCtx(WithUserID(ctx, 1), &TestAuditedItem{Name: "a"})
+	if err != nil || one.Creator != 1 || one.Modifier != 1 {
+		t.Fatalf("Expected creator and modifier 1, got %+v %v", one, err)
+	}
+
+	if err := UpdateCtx(WithUserID(ctx, 2), one.ID, func(one *TestAuditedItem) { one.Name = "b" }); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	err = NewQueryCtx[TestAuditedItem](WithUserID(ctx, 3)).Where("id", one.ID).Update(map[string]interface{}{"name": "c"})
+	if err != nil {
+		t.Fatalf("QueryBuilder update failed: %v", err)
+	}
+
+	one, _ = RetrieveCtx[TestAuditedItem](ctx, one.ID)
+	if one.Creator != 1 || one.Modifier != 3 || one.Name != "c" {
+		t.Errorf("Expected creator 1 and modifier 3, got %+v", one)
+	}
+
+	t.Run("Extractor", func(t *testing.T) {
+		type tenantKey struct{}
+		SetUserIDExtractor(func(ctx context.Context) uint {
+			id, _ := ctx.Value(tenantKey{}).(uint)
+			return id
+		})
+		defer SetUserIDExtractor(nil)
+
+		one, err := CreateCtx(context.WithValue(ctx, tenantKey{}, uint(9)), &TestAuditedItem{Name: "d"})
+		if err != nil || one.Creator != 9 {
+			t.Errorf("Expected creator 9, got %+v %v", one, err)
+		}
+	})
+
+	t.Run("User Of Zoox Context", func(t *testing.T) {
+		if id := userIDOf(&TestAuditedItem{ID: 7}); id != 7 {
+			t.Errorf("Expected user id 7, got %d", id)
+		}
+
+		if id := userIDOf(nil); id != 0 {
+			t.Errorf("Expected user id 0, got %d", id)
+		}
+	})
+}
@@ -0,0 +1,575 @@
+package gormx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	zlogger "github.com/go-zoox/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/plugin/dbresolver"
+)
+
+const (
+	// AuditCreate is the operation of the audit log of a created record.
+	AuditCreate = "create"
+	// AuditUpdate is the operation of the audit log of an updated record.
+	AuditUpdate = "update"
+	// AuditDelete is the operation of the audit log of a deleted record.
+	AuditDelete = "delete"
+)
+
+// AuditLog is a change of a record of a registered model, see EnableAuditLog.
+type AuditLog struct {
+	ID uint `gorm:"primarykey" json:"id"`
+	// Model is the registered name of the model
+	Model string `gorm:"size:128;index:idx_gormx_audit_log_record" json:"model"`
+	// RecordID is the primary key of the record
+	RecordID string `gorm:"size:128;index:idx_gormx_audit_log_record" json:"record_id"`
+	// Operation is one of AuditCreate, AuditUpdate and AuditDelete
+	Operation string `gorm:"size:16" json:"operation"`
+	// Actor is the current user ID of the context, see UserIDFromContext
+	Actor uint `gorm:"index" json:"actor"`
+	// Diff is the changed columns, a JSON object of column => AuditChange
+	Diff JSON `json:"diff"`
+
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName is the table of the audit logs, which is created by Migrate if the audit log is enabled.
+func (AuditLog) TableName() string {
+	return "gormx_audit_log"
+}
+
+// Changes returns the changes of the diff.
+func (l *AuditLog) Changes() (map[string]*AuditChange, error) {
+	changes := map[string]*AuditChange{}
+	if len(l.Diff) == 0 {
+		return changes, nil
+	}
+
+	if err := json.Unmarshal(l.Diff, &changes); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// AuditChange is the change of a column, Old is empty on create, and New is empty on delete.
+type AuditChange struct {
+	Old json.RawMessage `json:"old,omitempty"`
+	New json.RawMessage `json:"new,omitempty"`
+}
+
+var auditLogState = struct {
+	sync.RWMutex
+	enabled bool
+	// models is the names of the audited models, empty means all
+	models map[string]bool
+	// names caches the registered names of the model types, empty if not registered
+	names sync.Map
+}{}
+
+var errAuditModelFound = errors.New("audit model found")
+
+// EnableAuditLog enables the audit log of the registered models of the names,
+// or all registered models if there is no name. The creates, updates and deletes of
+// the models are logged into the AuditLog table in the same transaction.
+func EnableAuditLog(names ...string) {
+	auditLogState.Lock()
+	defer auditLogState.Unlock()
+
+	if !auditLogState.enabled || len(names) == 0 {
+		auditLogState.models = map[string]bool{}
+	}
+
+	auditLogState.enabled = true
+	for _, name := range names {
+		auditLogState.models[name] = true
+	}
+}
+
+// DisableAuditLog disables the audit log of all models.
+func DisableAuditLog() {
+	auditLogState.Lock()
+	defer auditLogState.Unlock()
+
+	auditLogState.enabled = false
+	auditLogState.models = nil
+}
+
+func isAuditLogEnabled() bool {
+	auditLogState.RLock()
+	defer auditLogState.RUnlock()
+
+	return auditLogState.enabled
+}
+
+// registeredModelName returns the registered name of the model type, see Register.
+func registeredModelName(typ reflect.Type) (string, bool) {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if name, ok := auditLogState.names.Load(typ); ok {
+		return name.(string), name != ""
+	}
+
+	var name string
+	if model == nil {
+		auditLogState.names.Store(typ, name)
+		return "", false
+	}
+
+	err := model.ForEach(func(id string, s any) error {
+		t := reflect.TypeOf(s)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+
+		if t == typ {
+			name = id
+			return errAuditModelFound
+		}
+
+		return nil
+	})
+	if err != errAuditModelFound {
+		name = ""
+	}
+
+	auditLogState.names.Store(typ, name)
+	return name, name != ""
+}
+
+// forgetUnregisteredModels drops the cached model types which were not registered,
+// which is called by Register.
+func forgetUnregisteredModels() {
+	auditLogState.names.Range(func(typ, name any) bool {
+		if name == "" {
+			auditLogState.names.Delete(typ)
+		}
+
+		return true
+	})
+}
+
+// auditedModelName returns the registered name of the model of the statement if it is audited.
+func auditedModelName(stmt *gorm.Statement) (string, bool) {
+	if stmt.Schema == nil || stmt.Schema.PrioritizedPrimaryField == nil || !isAuditLogEnabled() {
+		return "", false
+	}
+
+	name, ok := registeredModelName(stmt.Schema.ModelType)
+	if !ok {
+		return "", false
+	}
+
+	auditLogState.RLock()
+	defer auditLogState.RUnlock()
+
+	if len(auditLogState.models) != 0 && !auditLogState.models[name] {
+		return "", false
+	}
+
+	return name, true
+}
+
+// auditedModel returns the registered name of the model of the statement if it is audited,
+// and the audit log table exists on the connection of the statement.
+func auditedModel(db *gorm.DB) (string, bool) {
+	name, ok := auditedModelName(db.Statement)
+	if !ok || !hasAuditLogTable(db) {
+		return "", false
+	}
+
+	return name, true
+}
+
+// auditLogTables caches whether the audit log table exists by the connection pool,
+// which is shared by the sessions and transactions of a connection, reset by MigrateAuditLog.
+var auditLogTables sync.Map
+
+// hasAuditLogTable returns true if the audit log table exists on the connection of the db,
+// the audit logs are skipped with a warning if not, see MigrateAuditLog.
+func hasAuditLogTable(db *gorm.DB) bool {
+	if exists, ok := auditLogTables.Load(db.Config.ConnPool); ok {
+		return exists.(bool)
+	}
+
+	exists := auditSession(db).Migrator().HasTable(&AuditLog{})
+	if !exists {
+		zlogger.Warnf("[gormx][audit_log] table %s does not exist, the audit logs are skipped, see MigrateAuditLog", AuditLog{}.TableName())
+	}
+
+	auditLogTables.Store(db.Config.ConnPool, exists)
+	return exists
+}
+
+// MigrateAuditLog creates the audit log table on the default and all named connections,
+// which is called by Migrate if the audit log is enabled.
+func MigrateAuditLog() error {
+	dbs := map[string]*gorm.DB{}
+	if db != nil {
+		dbs[DefaultConnection] = db
+	}
+
+	connectionsLock.RLock()
+	for name, c := range connections {
+		if c.db != nil {
+			dbs[name] = c.db
+		}
+	}
+	connectionsLock.RUnlock()
+
+	for name, d := range dbs {
+		if err := d.Clauses(dbresolver.Write).AutoMigrate(&AuditLog{}); err != nil {
+			return fmt.Errorf("migrate the audit log of connection(%s) failed: %s", name, err)
+		}
+
+		auditLogTables.Delete(d.Config.ConnPool)
+	}
+
+	return nil
+}
+
+const auditLogBeforeKey = "gormx:audit_log_before"
+
+// auditSession returns a new session on the connection of the statement, e.g. its transaction.
+func auditSession(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{NewDB: true, SkipHooks: true})
+}
+
+// auditPrimaryKeys returns the primary keys of the records of the statement, if any.
+func auditPrimaryKeys(stmt *gorm.Statement) []interface{} {
+	pk := stmt.Schema.PrioritizedPrimaryField
+	keys := []interface{}{}
+
+	add := func(rv reflect.Value) {
+		if rv.Kind() != reflect.Struct || rv.Type() != stmt.Schema.ModelType {
+			return
+		}
+
+		if value, isZero := pk.ValueOf(stmt.Context, rv); !isZero {
+			keys = append(keys, value)
+		}
+	}
+
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			add(reflect.Indirect(stmt.ReflectValue.Index(i)))
+		}
+	case reflect.Struct:
+		add(stmt.ReflectValue)
+	}
+
+	return keys
+}
+
+// loadAuditRows loads the rows matching the where of the statement before it is executed.
+func loadAuditRows(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil {
+		return
+	}
+
+	if _, ok := auditedModel(db); !ok {
+		return
+	}
+
+	tx := auditSession(db).Model(reflect.New(stmt.Schema.ModelType).Interface()).Table(stmt.Table)
+	if stmt.Unscoped {
+		tx = tx.Unscoped()
+	}
+
+	hasCondition := false
+	if c, ok := stmt.Clauses["WHERE"]; ok {
+		if where, ok := c.Expression.(clause.Where); ok && len(where.Exprs) != 0 {
+			tx = tx.Clauses(where)
+			hasCondition = true
+		}
+	}
+
+	if keys := auditPrimaryKeys(stmt); len(keys) != 0 {
+		tx = tx.Where(clause.IN{Column: clause.Column{Name: stmt.Schema.PrioritizedPrimaryField.DBName}, Values: keys})
+		hasCondition = true
+	}
+
+	// the statement fails without a where, see ErrMissingWhereClause
+	if !hasCondition && !stmt.AllowGlobalUpdate {
+		return
+	}
+
+	rows := []map[string]interface{}{}
+	if err := tx.Find(&rows).Error; err != nil {
+		db.AddError(fmt.Errorf("load the audit rows failed: %w", err))
+		return
+	}
+
+	db.InstanceSet(auditLogBeforeKey, rows)
+}
+
+// auditRow encodes the columns of the row.
+func auditRow(row map[string]interface{}) (map[string]json.RawMessage, error) {
+	encoded := make(map[string]json.RawMessage, len(row))
+	for column, value := range row {
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("encode the audit value of %s failed: %s", column, err)
+		}
+
+		encoded[column] = raw
+	}
+
+	return encoded, nil
+}
+
+// auditDiff returns the diff of the rows, nil old for create and nil new for delete.
+func auditDiff(old, current map[string]interface{}) (JSON, bool, error) {
+	oldValues, err := auditRow(old)
+	if err != nil {
+		return nil, false, err
+	}
+
+	newValues, err := auditRow(current)
+	if err != nil {
+		return nil, false, err
+	}
+
+	diff := map[string]*AuditChange{}
+	for column, value := range oldValues {
+		if string(newValues[column]) != string(value) {
+			diff[column] = &AuditChange{Old: value, New: newValues[column]}
+		}
+	}
+	for column, value := range newValues {
+		if _, ok := oldValues[column]; !ok {
+			diff[column] = &AuditChange{New: value}
+		}
+	}
+
+	if len(diff) == 0 {
+		return nil, false, nil
+	}
+
+	data, err := json.Marshal(diff)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return JSON(data), true, nil
+}
+
+func writeAuditLogs(db *gorm.DB, logs []*AuditLog) {
+	if len(logs) == 0 {
+		return
+	}
+
+	if err := auditSession(db).Create(&logs).Error; err != nil {
+		db.AddError(fmt.Errorf("write the audit logs failed: %w", err))
+	}
+}
+
+func newAuditLog(stmt *gorm.Statement, name string, operation string, id interface{}, diff JSON) *AuditLog {
+	return &AuditLog{
+		Model:     name,
+		RecordID:  fmt.Sprint(id),
+		Operation: operation,
+		Actor:     UserIDFromContext(stmt.Context),
+		Diff:      diff,
+	}
+}
+
+// writeAuditLogsOnCreate logs the created records.
+func writeAuditLogsOnCreate(db *gorm.DB) {
+	stmt := db.Statement
+	if db.Error != nil || db.RowsAffected == 0 {
+		return
+	}
+
+	name, ok := auditedModel(db)
+	if !ok {
+		return
+	}
+
+	logs := []*AuditLog{}
+	add := func(rv reflect.Value) error {
+		if rv.Kind() != reflect.Struct || rv.Type() != stmt.Schema.ModelType {
+			return nil
+		}
+
+		id, isZero := stmt.Schema.PrioritizedPrimaryField.ValueOf(stmt.Context, rv)
+		// skipped by ON CONFLICT DO NOTHING
+		if isZero {
+			return nil
+		}
+
+		row := map[string]interface{}{}
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+
+			row[field.DBName], _ = field.ValueOf(stmt.Context, rv)
+		}
+
+		diff, _, err := auditDiff(nil, row)
+		if err != nil {
+			return err
+		}
+
+		logs = append(logs, newAuditLog(stmt, name, AuditCreate, id, diff))
+		return nil
+	}
+
+	var err error
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len() && err == nil; i++ {
+			err = add(reflect.Indirect(stmt.ReflectValue.Index(i)))
+		}
+	case reflect.Struct:
+		err = add(stmt.ReflectValue)
+	}
+	if err != nil {
+		db.AddError(err)
+		return
+	}
+
+	writeAuditLogs(db, logs)
+}
+
+// beforeAuditRows returns the rows loaded by loadAuditRows.
+func beforeAuditRows(db *gorm.DB) []map[string]interface{} {
+	if db.Error != nil || db.RowsAffected == 0 {
+		return nil
+	}
+
+	rows, _ := db.InstanceGet(auditLogBeforeKey)
+	before, _ := rows.([]map[string]interface{})
+	return before
+}
+
+// writeAuditLogsOnUpdate logs the changes of the updated records.
+func writeAuditLogsOnUpdate(db *gorm.DB) {
+	stmt := db.Statement
+	before := beforeAuditRows(db)
+	if len(before) == 0 {
+		return
+	}
+
+	name, ok := auditedModel(db)
+	if !ok {
+		return
+	}
+
+	pk := stmt.Schema.PrioritizedPrimaryField.DBName
+	keys := make([]interface{}, 0, len(before))
+	for _, row := range before {
+		keys = append(keys, row[pk])
+	}
+
+	after := []map[string]interface{}{}
+	err := auditSession(db).
+		Model(reflect.New(stmt.Schema.ModelType).Interface()).
+		Table(stmt.Table).
+		Unscoped().
+		Where(clause.IN{Column: clause.Column{Name: pk}, Values: keys}).
+		Find(&after).
+		Error
+	if err != nil {
+		db.AddError(fmt.Errorf("load the audit rows failed: %w", err))
+		return
+	}
+
+	afterByID := make(map[string]map[string]interface{}, len(after))
+	for _, row := range after {
+		afterByID[fmt.Sprint(row[pk])] = row
+	}
+
+	logs := []*AuditLog{}
+	for _, old := range before {
+		id := fmt.Sprint(old[pk])
+		diff, changed, err := auditDiff(old, afterByID[id])
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+
+		if changed {
+			logs = append(logs, newAuditLog(stmt, name, AuditUpdate, id, diff))
+		}
+	}
+
+	writeAuditLogs(db, logs)
+}
+
+// writeAuditLogsOnDelete logs the deleted records.
+func writeAuditLogsOnDelete(db *gorm.DB) {
+	stmt := db.Statement
+	before := beforeAuditRows(db)
+	if len(before) == 0 {
+		return
+	}
+
+	name, ok := auditedModel(db)
+	if !ok {
+		return
+	}
+
+	pk := stmt.Schema.PrioritizedPrimaryField.DBName
+	logs := []*AuditLog{}
+	for _, old := range before {
+		diff, _, err := auditDiff(old, nil)
+		if err != nil {
+			db.AddError(err)
+			return
+		}
+
+		logs = append(logs, newAuditLog(stmt, name, AuditDelete, old[pk], diff))
+	}
+
+	writeAuditLogs(db, logs)
+}
+
+// GetAuditHistory returns the audit logs of the record of the registered model T, oldest first.
+func GetAuditHistory[T any](id any) ([]*AuditLog, error) {
+	return GetAuditHistoryCtx[T](context.Background(), id)
+}
+
+// GetAuditHistoryCtx returns the audit logs of the record of the registered model T with context.
+func GetAuditHistoryCtx[T any](ctx context.Context, id any) ([]*AuditLog, error) {
+	name, ok := registeredModelName(reflect.TypeOf(new(T)))
+	if !ok {
+		var one T
+		return nil, fmt.Errorf("model %T is not registered", one)
+	}
+
+	return GetAuditHistoryByNameCtx(ctx, name, id)
+}
+
+// GetAuditHistoryByName returns the audit logs of the record of the registered model name, oldest first.
+func GetAuditHistoryByName(name string, id any) ([]*AuditLog, error) {
+	return GetAuditHistoryByNameCtx(context.Background(), name, id)
+}
+
+// GetAuditHistoryByNameCtx returns the audit logs of the record of the registered model name with context.
+func GetAuditHistoryByNameCtx(ctx context.Context, name string, id any) ([]*AuditLog, error) {
+	logs := []*AuditLog{}
+	err := GetDBWithContext(ctx).
+		Where("model = ? AND record_id = ?", name, fmt.Sprint(id)).
+		Order("id").
+		Find(&logs).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
@@ -0,0 +1,142 @@
+package gormx
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type TestAuditLogItem struct {
+	ModelImpl
+	Name string `gorm:"column:name"`
+}
+
+func (TestAuditLogItem) ModelName() string {
+	return "test_audit_log_item"
+}
+
+func TestAuditLog(t *testing.T) {
+	Register("test_audit_log_item", &TestAuditLogItem{})
+	EnableAuditLog("test_audit_log_item")
+	defer DisableAuditLog()
+
+	ctx := openTestDB(t, "test_audit_log", &TestAuditLogItem{})
+
+	t.Run("Missing Table", func(t *testing.T) {
+		one, err := CreateCtx(ctx, &TestAuditLogItem{Name: "untracked"})
+		if err != nil {
+			t.Fatalf("Expected the audit logs to be skipped without the table, got %v", err)
+		}
+
+		if err := DeleteOneByIDCtx[TestAuditLogItem](ctx, one.ID); err != nil {
+			t.Fatalf("Expected the audit logs to be skipped without the table, got %v", err)
+		}
+
+		// the sessions and transactions of the connection share the cached check
+		for i := 0; i < 5; i++ {
+			if _, err := CreateCtx(ctx, &TestAuditLogItem{Name: "untracked"}); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+		}
+
+		tables := 0
+		auditLogTables.Range(func(pool, _ any) bool {
+			if pool == GetNamedDB("test_audit_log").ConnPool {
+				tables++
+			}
+			return true
+		})
+		if tables != 1 {
+			t.Errorf("Expected the table check cached once for the connection, got %d", tables)
+		}
+	})
+
+	if err := MigrateAuditLog(); err != nil {
+		t.Fatalf("MigrateAuditLog failed: %v", err)
+	}
+
+	one, err := CreateCtx(WithUserID(ctx, 1), &TestAuditLogItem{Name: "a"})
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	if err := UpdateCtx(WithUserID(ctx, 2), one.ID, func(one *TestAuditLogItem) { one.Name = "b" }); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	if _, err := UpdateManyCtx[TestAuditLogItem](WithUserID(ctx, 3), map[any]any{"id": one.ID}, map[string]any{"name": "c"}); err != nil {
+		t.Fatalf("UpdateMany failed: %v", err)
+	}
+
+	if err := DeleteOneByIDCtx[TestAuditLogItem](WithUserID(ctx, 4), one.ID); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+
+	logs, err := GetAuditHistoryCtx[TestAuditLogItem](ctx, one.ID)
+	if err != nil {
+		t.Fatalf("GetAuditHistory failed: %v", err)
+	}
+
+	expected := []struct {
+		operation string
+		actor     uint
+		old, new  string
+	}{
+		{AuditCreate, 1, ``, `"a"`},
+		{AuditUpdate, 2, `"a"`, `"b"`},
+		{AuditUpdate, 3, `"b"`, `"c"`},
+		{AuditDelete, 4, `"c"`, ``},
+	}
+	if len(logs) != len(expected) {
+		t.Fatalf("Expected %d audit logs, got %d", len(expected), len(logs))
+	}
+
+	for i, e := range expected {
+		log := logs[i]
+		if log.Model != "test_audit_log_item" || log.Operation != e.operation || log.Actor != e.actor {
+			t.Errorf("Expected audit log %d to be %s by %d, got %+v", i, e.operation, e.actor, log)
+			continue
+		}
+
+		changes, err := log.Changes()
+		if err != nil {
+			t.Fatalf("Changes failed: %v", err)
+		}
+
+		name := changes["name"]
+		if name == nil || string(name.Old) != e.old || string(name.New) != e.new {
+			t.Errorf("Expected audit log %d to change name from %s to %s, got %s", i, e.old, e.new, log.Diff)
+		}
+	}
+
+	t.Run("Not Audited", func(t *testing.T) {
+		if err := GetDBWithContext(ctx).AutoMigrate(&TestAuditedItem{}); err != nil {
+			t.Fatalf("AutoMigrate failed: %v", err)
+		}
+
+		if _, err := CreateCtx(ctx, &TestAuditedItem{Name: "a"}); err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+
+		var count int64
+		GetDBWithContext(ctx).Model(&AuditLog{}).Count(&count)
+		if count != int64(len(expected)) {
+			t.Errorf("Expected %d audit logs, got %d", len(expected), count)
+		}
+	})
+
+	t.Run("Unchanged", func(t *testing.T) {
+		two, _ := CreateCtx(ctx, &TestAuditLogItem{Name: "x"})
+		if _, err := UpdateManyCtx[TestAuditLogItem](ctx, map[any]any{"id": two.ID}, map[string]any{"name": "x"}); err != nil {
+			t.Fatalf("UpdateMany failed: %v", err)
+		}
+
+		logs, _ := GetAuditHistoryCtx[TestAuditLogItem](ctx, two.ID)
+		for _, log := range logs[1:] {
+			var diff map[string]json.RawMessage
+			json.Unmarshal(log.Diff, &diff)
+			if _, ok := diff["name"]; ok {
+				t.Errorf("Expected no change of name, got %s", log.Diff)
+			}
+		}
+	})
+}
@@ -0,0 +1,166 @@
+package gormx
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// DefaultBatchSize is the batch size of the bulk operations if not specified.
+const DefaultBatchSize = 100
+
+// BatchOptions is the options of the bulk operations.
+type BatchOptions struct {
+	// BatchSize is the number of records per statement, default is DefaultBatchSize.
+	BatchSize int
+}
+
+func newBatchOptions(opts ...func(*BatchOptions)) *BatchOptions {
+	opt := &BatchOptions{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	if opt.BatchSize <= 0 {
+		opt.BatchSize = DefaultBatchSize
+	}
+
+	return opt
+}
+
+// bulkWhere builds the where of the bulk update and delete, which cannot be empty.
+func bulkWhere[W WhereCondition](tx *gorm.DB, where W) (*gorm.DB, error) {
+	w := ToWhere(where)
+	if w == nil {
+		return nil, ErrMissingWhereClause
+	}
+
+	whereClause, whereValues, err := w.BuildFor(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	if whereClause == "" {
+		return nil, ErrMissingWhereClause
+	}
+
+	return tx.Where(whereClause, whereValues...), nil
+}
+
+// CreateMany creates the records in batches in a single transaction,
+// returns the number of created records.
+func CreateMany[T any](data []*T, opts ...func(*BatchOptions)) (int64, error) {
+	return CreateManyCtx(context.Background(), data, opts...)
+}
+
+// CreateManyCtx creates the records in batches in a single transaction with context.
+func CreateManyCtx[T any](ctx context.Context, data []*T, opts ...func(*BatchOptions)) (affected int64, err error) {
+	if len(data) == 0 {
+		return 0, nil
+	}
+
+	opt := newBatchOptions(opts...)
+
+	err = GetDBWithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.CreateInBatches(data, opt.BatchSize)
+		affected = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return affected, nil
+}
+
+// UpdateMany updates the columns of the records matching the where by a single statement
+// in a transaction, returns the number of updated records. The where cannot be empty.
+func UpdateMany[T any, W WhereCondition](where W, updates map[string]any) (int64, error) {
+	return UpdateManyCtx[T](context.Background(), where, updates)
+}
+
+// UpdateManyCtx updates the columns of the records matching the where in a single transaction with context.
+func UpdateManyCtx[T any, W WhereCondition](ctx context.Context, where W, updates map[string]any) (affected int64, err error) {
+	if len(updates) == 0 {
+		return 0, nil
+	}
+
+	err = GetDBWithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tx, err := bulkWhere(tx.Model(new(T)), where)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Updates(updates)
+		affected = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return affected, nil
+}
+
+// DeleteMany deletes the records matching the where by a single statement
+// in a transaction, returns the number of deleted records. The where cannot be empty.
+func DeleteMany[T any, W WhereCondition](where W) (int64, error) {
+	return DeleteManyCtx[T](context.Background(), where)
+}
+
+// DeleteManyCtx deletes the records matching the where in a single transaction with context.
+func DeleteManyCtx[T any, W WhereCondition](ctx context.Context, where W) (affected int64, err error) {
+	err = GetDBWithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		tx, err := bulkWhere(tx.Model(new(T)), where)
+		if err != nil {
+			return err
+		}
+
+		result := tx.Delete(new(T))
+		affected = result.RowsAffected
+		return result.Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return affected, nil
+}
+
+// DeleteManyByIDs deletes the records of the ids in batches in a single transaction,
+// returns the number of deleted records.
+func DeleteManyByIDs[T any](ids []uint, opts ...func(*BatchOptions)) (int64, error) {
+	return DeleteManyByIDsCtx[T](context.Background(), ids, opts...)
+}
+
+// DeleteManyByIDsCtx deletes the records of the ids in batches in a single transaction with context.
+func DeleteManyByIDsCtx[T any](ctx context.Context, ids []uint, opts ...func(*BatchOptions)) (affected int64, err error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	opt := newBatchOptions(opts...)
+
+	err = GetDBWithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for start := 0; start < len(ids); start += opt.BatchSize {
+			end := start + opt.BatchSize
+			if end > len(ids) {
+				end = len(ids)
+			}
+
+			result := tx.Delete(new(T), ids[start:end])
+			if result.Error != nil {
+				return result.Error
+			}
+
+			affected += result.RowsAffected
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return affected, nil
+}
@@ -0,0 +1,63 @@
+package gormx
+
+import (
+	"fmt"
+	"testing"
+)
+
+type TestBulkItem struct {
+	ID       uint   `gorm:"primarykey"`
+	Name     string `gorm:"column:name;uniqueIndex"`
+	Category string `gorm:"column:category"`
+}
+
+func TestBulkOperations(t *testing.T) {
+	openTestDB(t, "test_bulk", &TestBulkItem{})
+
+	m := (&ModelGeneric[TestBulkItem]{}).UseConnection("test_bulk")
+
+	data := []*TestBulkItem{}
+	for i := 0; i < 5; i++ {
+		data = append(data, &TestBulkItem{Name: fmt.Sprintf("item-%d", i), Category: "a"})
+	}
+
+	created, err := m.CreateMany(data, func(opt *BatchOptions) { opt.BatchSize = 2 })
+	if err != nil || created != 5 {
+		t.Fatalf("Expected 5 created, got %d %v", created, err)
+	}
+
+	t.Run("Rollback", func(t *testing.T) {
+		// the duplicate in the second batch rolls back the first one
+		_, err := m.CreateMany([]*TestBulkItem{{Name: "new"}, {Name: "item-0"}}, func(opt *BatchOptions) { opt.BatchSize = 1 })
+		if err == nil {
+			t.Fatal("Expected duplicate error")
+		}
+
+		if exists, _ := m.Exists(map[any]any{"name": "new"}); exists {
+			t.Error("Expected the first batch to be rolled back")
+		}
+	})
+
+	where := NewWhere()
+	where.Set("id", []uint{1, 2}, &SetWhereOptions{IsIn: true})
+	updated, err := m.UpdateMany(where, map[string]any{"category": "b"})
+	if err != nil || updated != 2 {
+		t.Errorf("Expected 2 updated, got %d %v", updated, err)
+	}
+
+	if _, err := m.UpdateMany(NewWhere(), map[string]any{"category": "c"}); !IsMissingWhereClauseError(err) {
+		t.Errorf("Expected missing where clause error, got %v", err)
+	}
+
+	where = NewWhere()
+	where.Set("category", "b")
+	deleted, err := m.DeleteMany(where)
+	if err != nil || deleted != 2 {
+		t.Errorf("Expected 2 deleted, got %d %v", deleted, err)
+	}
+
+	deleted, err = m.DeleteManyByIDs([]uint{3, 4, 5, 6}, func(opt *BatchOptions) { opt.BatchSize = 3 })
+	if err != nil || deleted != 3 {
+		t.Errorf("Expected 3 deleted, got %d %v", deleted, err)
+	}
+}
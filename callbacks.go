@@ -0,0 +1,46 @@
+package gormx
+
+import "gorm.io/gorm"
+
+// registerCallbacks registers the gormx callbacks of the db, which is idempotent.
+func registerCallbacks(db *gorm.DB) error {
+	if db.Callback().Create().Get("gormx:audit_fields") == nil {
+		if err := db.Callback().Create().Before("gorm:create").Register("gormx:audit_fields", setAuditFieldsOnCreate); err != nil {
+			return err
+		}
+	}
+
+	if db.Callback().Update().Get("gormx:audit_fields") == nil {
+		if err := db.Callback().Update().Before("gorm:update").Register("gormx:audit_fields", setAuditFieldsOnUpdate); err != nil {
+			return err
+		}
+	}
+
+	if db.Callback().Create().Get("gormx:audit_log") == nil {
+		if err := db.Callback().Create().After("gorm:create").Register("gormx:audit_log", writeAuditLogsOnCreate); err != nil {
+			return err
+		}
+	}
+
+	if db.Callback().Update().Get("gormx:audit_log") == nil {
+		if err := db.Callback().Update().Before("gorm:update").Register("gormx:audit_log_before", loadAuditRows); err != nil {
+			return err
+		}
+
+		if err := db.Callback().Update().After("gorm:update").Register("gormx:audit_log", writeAuditLogsOnUpdate); err != nil {
+			return err
+		}
+	}
+
+	if db.Callback().Delete().Get("gormx:audit_log") == nil {
+		if err := db.Callback().Delete().Before("gorm:delete").Register("gormx:audit_log_before", loadAuditRows); err != nil {
+			return err
+		}
+
+		if err := db.Callback().Delete().After("gorm:delete").Register("gormx:audit_log", writeAuditLogsOnDelete); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
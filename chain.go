@@ -1,11 +1,13 @@
 package gormx
 
 import (
+	"context"
 	"fmt"
 	"reflect"
 	"strings"
 
 	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
 )
 
 // QueryBuilder provides a fluent interface for building database queries
@@ -22,8 +24,34 @@ type QueryBuilder[T any] struct {
 	group    []string
 	having   *Where
 	distinct bool
+	trashed  trashedScope
+	// immutable => every chain method returns a new builder, see Immutable
+	immutable bool
+	// allowGlobal => Delete and Update may run without a WHERE, see AllowGlobal
+	allowGlobal bool
 }
 
+// trashedScope is the scope of the soft deleted records of a query.
+type trashedScope int
+
+const (
+	withoutTrashed trashedScope = iota
+	withTrashed
+	onlyTrashed
+)
+
+// deleteMode is how the soft delete scope of a query is applied.
+type deleteMode int
+
+const (
+	// noDelete => a read or update, the WithTrashed and OnlyTrashed queries are unscoped
+	noDelete deleteMode = iota
+	// softDelete => never unscoped, so the soft deletable records are soft deleted
+	softDelete
+	// forceDelete => always unscoped, so the records are deleted permanently
+	forceDelete
+)
+
 // JoinClause represents a join clause
 type JoinClause struct {
 	Type      string // "INNER", "LEFT", "RIGHT", "FULL"
@@ -34,8 +62,23 @@ type JoinClause struct {
 
 // NewQuery creates a new query builder for the given model type
 func NewQuery[T any]() *QueryBuilder[T] {
+	return newQuery[T](GetDB())
+}
+
+// NewQueryCtx creates a new query builder bound to the given context,
+// using the connection pinned in the context if any
+func NewQueryCtx[T any](ctx context.Context) *QueryBuilder[T] {
+	return newQuery[T](GetDBWithContext(ctx))
+}
+
+// NewQueryOn creates a new query builder on the named connection
+func NewQueryOn[T any](name string) *QueryBuilder[T] {
+	return newQuery[T](GetNamedDB(name))
+}
+
+func newQuery[T any](db *gorm.DB) *QueryBuilder[T] {
 	return &QueryBuilder[T]{
-		db:       GetDB(),
+		db:       db,
 		model:    new(T),
 		where:    NewWhere(),
 		orders:   &OrderBy{},
@@ -46,12 +89,93 @@ func NewQuery[T any]() *QueryBuilder[T] {
 	}
 }
 
+// WithContext binds the query to the given context, so cancellation and
+// deadlines propagate to the underlying database calls
+func (q *QueryBuilder[T]) WithContext(ctx context.Context) *QueryBuilder[T] {
+	q = q.mutate()
+	q.db = q.db.WithContext(ctx)
+	return q
+}
+
+// UseConnection switches the query to the named connection, keeping its context
+func (q *QueryBuilder[T]) UseConnection(name string) *QueryBuilder[T] {
+	q = q.mutate()
+	q.db = GetNamedDB(name).WithContext(q.db.Statement.Context)
+	return q
+}
+
+// UsePrimary routes the reads of the query to the primary instead of the replicas,
+// for read-after-write consistency
+func (q *QueryBuilder[T]) UsePrimary() *QueryBuilder[T] {
+	q = q.mutate()
+	q.db = q.db.Clauses(dbresolver.Write)
+	return q
+}
+
 // Where adds a WHERE condition to the query
 func (q *QueryBuilder[T]) Where(field string, value interface{}, opts ...*SetWhereOptions) *QueryBuilder[T] {
+	q = q.mutate()
 	q.where.Set(field, value, opts...)
 	return q
 }
 
+// OrWhere adds a WHERE condition joined by OR
+func (q *QueryBuilder[T]) OrWhere(field string, value interface{}, opts ...*SetWhereOptions) *QueryBuilder[T] {
+	q = q.mutate()
+	opt := &SetWhereOptions{}
+	for _, o := range opts {
+		if o != nil {
+			*opt = *o
+		}
+	}
+	opt.IsOr = true
+
+	q.where.Add(field, value, opt)
+	return q
+}
+
+// WhereGroup adds a parenthesised group of WHERE conditions joined by AND
+func (q *QueryBuilder[T]) WhereGroup(fn func(w *Where)) *QueryBuilder[T] {
+	q = q.mutate()
+	sub := NewWhere()
+	fn(sub)
+	q.where.And(sub)
+	return q
+}
+
+// OrWhereGroup adds a parenthesised group of WHERE conditions joined by OR
+func (q *QueryBuilder[T]) OrWhereGroup(fn func(w *Where)) *QueryBuilder[T] {
+	q = q.mutate()
+	sub := NewWhere()
+	fn(sub)
+	q.where.Or(sub)
+	return q
+}
+
+// WhereNotGroup adds a negated group of WHERE conditions joined by AND
+func (q *QueryBuilder[T]) WhereNotGroup(fn func(w *Where)) *QueryBuilder[T] {
+	q = q.mutate()
+	sub := NewWhere()
+	fn(sub)
+	q.where.Not(sub)
+	return q
+}
+
+// Search adds a full text search of the keyword on the fields
+func (q *QueryBuilder[T]) Search(keyword string, fields ...string) *QueryBuilder[T] {
+	q = q.mutate()
+	q.where.FullTextSearchFields = fields
+	q.where.Set("q", keyword)
+	return q
+}
+
+// UseFullTextSearch sets the full text search strategy, instead of the one of the model
+func (q *QueryBuilder[T]) UseFullTextSearch(strategy FullTextSearch) *QueryBuilder[T] {
+	q = q.mutate()
+	q.where.FullTextSearch = strategy
+	return q
+}
+
 // WhereEqual adds an equality WHERE condition
 func (q *QueryBuilder[T]) WhereEqual(field string, value interface{}) *QueryBuilder[T] {
 	return q.Where(field, value, &SetWhereOptions{IsEqual: true})
@@ -77,28 +201,76 @@ func (q *QueryBuilder[T]) WhereLike(field string, value string) *QueryBuilder[T]
 	return q.Where(field, value, &SetWhereOptions{IsFuzzy: true})
 }
 
+// WhereStartsWith adds a prefix LIKE WHERE condition
+func (q *QueryBuilder[T]) WhereStartsWith(field string, value string) *QueryBuilder[T] {
+	return q.addWhere(field, value, &SetWhereOptions{IsStartsWith: true})
+}
+
+// WhereEndsWith adds a suffix LIKE WHERE condition
+func (q *QueryBuilder[T]) WhereEndsWith(field string, value string) *QueryBuilder[T] {
+	return q.addWhere(field, value, &SetWhereOptions{IsEndsWith: true})
+}
+
+// WhereGt adds a > WHERE condition, which can be combined with other comparisons on the same field
+func (q *QueryBuilder[T]) WhereGt(field string, value interface{}) *QueryBuilder[T] {
+	return q.addWhere(field, value, &SetWhereOptions{IsGreaterThan: true})
+}
+
+// WhereGte adds a >= WHERE condition, which can be combined with other comparisons on the same field
+func (q *QueryBuilder[T]) WhereGte(field string, value interface{}) *QueryBuilder[T] {
+	return q.addWhere(field, value, &SetWhereOptions{IsGreaterOrEqual: true})
+}
+
+// WhereLt adds a < WHERE condition, which can be combined with other comparisons on the same field
+func (q *QueryBuilder[T]) WhereLt(field string, value interface{}) *QueryBuilder[T] {
+	return q.addWhere(field, value, &SetWhereOptions{IsLessThan: true})
+}
+
+// WhereLte adds a <= WHERE condition, which can be combined with other comparisons on the same field
+func (q *QueryBuilder[T]) WhereLte(field string, value interface{}) *QueryBuilder[T] {
+	return q.addWhere(field, value, &SetWhereOptions{IsLessOrEqual: true})
+}
+
 // WhereBetween adds a BETWEEN WHERE condition
 func (q *QueryBuilder[T]) WhereBetween(field string, start, end interface{}) *QueryBuilder[T] {
-	// This would need to be implemented in the Where.Build() method
-	q.where.Add(field, []interface{}{start, end}, &SetWhereOptions{IsPlain: true})
+	return q.addWhere(field, []interface{}{start, end}, &SetWhereOptions{IsBetween: true})
+}
+
+// WhereNull adds an IS NULL WHERE condition
+func (q *QueryBuilder[T]) WhereNull(field string) *QueryBuilder[T] {
+	return q.addWhere(field, nil, &SetWhereOptions{IsNull: true})
+}
+
+// WhereNotNull adds an IS NOT NULL WHERE condition
+func (q *QueryBuilder[T]) WhereNotNull(field string) *QueryBuilder[T] {
+	return q.addWhere(field, nil, &SetWhereOptions{IsNotNull: true})
+}
+
+// addWhere adds a WHERE condition of a range, null or prefix operator, which can be combined
+// with the other conditions on the same field, unlike Where which replaces them
+func (q *QueryBuilder[T]) addWhere(field string, value interface{}, opt *SetWhereOptions) *QueryBuilder[T] {
+	q = q.mutate()
+	q.where.Add(field, value, opt)
 	return q
 }
 
-// WhereRaw adds a raw WHERE condition
+// WhereRaw adds a raw WHERE condition, built parenthesised with its args in order
 func (q *QueryBuilder[T]) WhereRaw(sql string, args ...interface{}) *QueryBuilder[T] {
-	q.where.Add("", nil, &SetWhereOptions{IsPlain: true})
-	// Store raw SQL separately - this would need special handling in Build()
+	q = q.mutate()
+	q.where.AddRaw(sql, args...)
 	return q
 }
 
 // Select specifies the columns to be selected
 func (q *QueryBuilder[T]) Select(columns ...string) *QueryBuilder[T] {
+	q = q.mutate()
 	q.selects = append(q.selects, columns...)
 	return q
 }
 
 // OrderBy adds an ORDER BY clause
 func (q *QueryBuilder[T]) OrderBy(field string, desc ...bool) *QueryBuilder[T] {
+	q = q.mutate()
 	isDesc := false
 	if len(desc) > 0 {
 		isDesc = desc[0]
@@ -107,6 +279,11 @@ func (q *QueryBuilder[T]) OrderBy(field string, desc ...bool) *QueryBuilder[T] {
 	return q
 }
 
+// OrderByRank orders by the relevance score of the full text search, best first
+func (q *QueryBuilder[T]) OrderByRank() *QueryBuilder[T] {
+	return q.OrderBy(RankOrderKey, true)
+}
+
 // OrderByAsc adds an ascending ORDER BY clause
 func (q *QueryBuilder[T]) OrderByAsc(field string) *QueryBuilder[T] {
 	return q.OrderBy(field, false)
@@ -119,18 +296,21 @@ func (q *QueryBuilder[T]) OrderByDesc(field string) *QueryBuilder[T] {
 
 // Limit sets the LIMIT clause
 func (q *QueryBuilder[T]) Limit(limit int) *QueryBuilder[T] {
+	q = q.mutate()
 	q.limit = &limit
 	return q
 }
 
 // Offset sets the OFFSET clause
 func (q *QueryBuilder[T]) Offset(offset int) *QueryBuilder[T] {
+	q = q.mutate()
 	q.offset = &offset
 	return q
 }
 
 // Page sets both LIMIT and OFFSET for pagination
 func (q *QueryBuilder[T]) Page(page, pageSize int) *QueryBuilder[T] {
+	q = q.mutate()
 	if pageSize > 0 {
 		q.limit = &pageSize
 	}
@@ -143,6 +323,7 @@ func (q *QueryBuilder[T]) Page(page, pageSize int) *QueryBuilder[T] {
 
 // Join adds a JOIN clause
 func (q *QueryBuilder[T]) Join(table, condition string, args ...interface{}) *QueryBuilder[T] {
+	q = q.mutate()
 	q.joins = append(q.joins, JoinClause{
 		Type:      "INNER",
 		Table:     table,
@@ -154,6 +335,7 @@ func (q *QueryBuilder[T]) Join(table, condition string, args ...interface{}) *Qu
 
 // LeftJoin adds a LEFT JOIN clause
 func (q *QueryBuilder[T]) LeftJoin(table, condition string, args ...interface{}) *QueryBuilder[T] {
+	q = q.mutate()
 	q.joins = append(q.joins, JoinClause{
 		Type:      "LEFT",
 		Table:     table,
@@ -165,6 +347,7 @@ func (q *QueryBuilder[T]) LeftJoin(table, condition string, args ...interface{})
 
 // RightJoin adds a RIGHT JOIN clause
 func (q *QueryBuilder[T]) RightJoin(table, condition string, args ...interface{}) *QueryBuilder[T] {
+	q = q.mutate()
 	q.joins = append(q.joins, JoinClause{
 		Type:      "RIGHT",
 		Table:     table,
@@ -176,37 +359,125 @@ func (q *QueryBuilder[T]) RightJoin(table, condition string, args ...interface{}
 
 // Preload adds a PRELOAD clause for eager loading associations
 func (q *QueryBuilder[T]) Preload(associations ...string) *QueryBuilder[T] {
+	q = q.mutate()
 	q.preloads = append(q.preloads, associations...)
 	return q
 }
 
 // GroupBy adds a GROUP BY clause
 func (q *QueryBuilder[T]) GroupBy(fields ...string) *QueryBuilder[T] {
+	q = q.mutate()
 	q.group = append(q.group, fields...)
 	return q
 }
 
 // Having adds a HAVING clause
 func (q *QueryBuilder[T]) Having(field string, value interface{}, opts ...*SetWhereOptions) *QueryBuilder[T] {
+	q = q.mutate()
 	q.having.Set(field, value, opts...)
 	return q
 }
 
+// HavingRaw adds a raw HAVING condition, built parenthesised with its args in order
+func (q *QueryBuilder[T]) HavingRaw(sql string, args ...interface{}) *QueryBuilder[T] {
+	q = q.mutate()
+	q.having.AddRaw(sql, args...)
+	return q
+}
+
 // Distinct adds a DISTINCT clause
 func (q *QueryBuilder[T]) Distinct() *QueryBuilder[T] {
+	q = q.mutate()
 	q.distinct = true
 	return q
 }
 
-// buildQuery builds the final GORM query
+// WithTrashed includes the soft deleted records in the query.
+func (q *QueryBuilder[T]) WithTrashed() *QueryBuilder[T] {
+	q = q.mutate()
+	q.trashed = withTrashed
+	return q
+}
+
+// OnlyTrashed limits the query to the soft deleted records, which is empty if T is not soft deletable.
+func (q *QueryBuilder[T]) OnlyTrashed() *QueryBuilder[T] {
+	q = q.mutate()
+	q.trashed = onlyTrashed
+	return q
+}
+
+// AllowGlobal allows Delete and Update to run without a WHERE, affecting all records.
+// The joins and Raw are not WHERE conditions, they need AllowGlobal too.
+func (q *QueryBuilder[T]) AllowGlobal() *QueryBuilder[T] {
+	q = q.mutate()
+	q.allowGlobal = true
+	return q
+}
+
+// buildQuery builds the final GORM query, the errors of building it, e.g. an invalid where,
+// are added to the query as a QueryBuildError, so it does not run and returns them
 func (q *QueryBuilder[T]) buildQuery() *gorm.DB {
-	query := q.db.Model(q.model)
+	query, _ := q.build(noDelete)
+	return query
+}
+
+// buildWriteQuery builds the query of Delete and Update, which refuses to run
+// with ErrMissingWhereClause if the query has no WHERE, unless AllowGlobal
+func (q *QueryBuilder[T]) buildWriteQuery(mode deleteMode) *gorm.DB {
+	query, hasWhere := q.build(mode)
+	if q.allowGlobal {
+		return query.Session(&gorm.Session{AllowGlobalUpdate: true})
+	}
+
+	// the where is missing if it failed to build, which is the error to return
+	if !hasWhere && query.Error == nil {
+		query.AddError(ErrMissingWhereClause)
+	}
+
+	return query
+}
+
+// build builds the final GORM query, and whether it has WHERE conditions: only the Where
+// conditions count, not the joins or Raw, and the soft delete scope only counts
+// for the OnlyTrashed ForceDelete, which purges the soft deleted records
+func (q *QueryBuilder[T]) build(mode deleteMode) (query *gorm.DB, hasWhere bool) {
+	errs := []error{}
+	defer func() {
+		if len(errs) != 0 {
+			query.AddError(&QueryBuildError{Errors: errs})
+		}
+	}()
+
+	// a new session, the statement of q.db must not be changed by the query
+	query = q.db.Session(&gorm.Session{}).Model(q.model)
+
+	// Apply the soft delete scope
+	if mode == forceDelete || (mode == noDelete && q.trashed != withoutTrashed) {
+		query = query.Unscoped()
+	}
+	switch q.trashed {
+	case withoutTrashed:
+		// the unscoped force delete still keeps the soft deleted records out
+		if field, err := deletedAtField[T](); err == nil && mode == forceDelete {
+			query = query.Where(notTrashedCondition(field))
+		}
+	case onlyTrashed:
+		if field, err := deletedAtField[T](); err == nil {
+			query = query.Where(trashedCondition(field))
+			hasWhere = mode == forceDelete
+		} else {
+			query = query.Where("1 = 0")
+		}
+	}
 
 	// Apply WHERE conditions
 	if q.where != nil && len(q.where.Items) > 0 {
-		whereClause, whereValues, err := q.where.Build()
-		if err == nil && whereClause != "" {
+		whereClause, whereValues, err := q.where.BuildFor(query)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("where: %w", err))
+		} else if whereClause != "" {
 			query = query.Where(whereClause, whereValues...)
+			hasWhere = true
 		}
 	}
 
@@ -227,12 +498,10 @@ func (q *QueryBuilder[T]) buildQuery() *gorm.DB {
 
 	// Apply ORDER BY
 	if q.orders != nil && len(*q.orders) > 0 {
-		for _, order := range *q.orders {
-			orderMod := "ASC"
-			if order.IsDESC {
-				orderMod = "DESC"
-			}
-			query = query.Order(fmt.Sprintf("%s %s", order.Key, orderMod))
+		if ordered, err := q.orders.apply(query, q.where); err != nil {
+			errs = append(errs, fmt.Errorf("order by: %w", err))
+		} else {
+			query = ordered
 		}
 	}
 
@@ -243,8 +512,10 @@ func (q *QueryBuilder[T]) buildQuery() *gorm.DB {
 
 	// Apply HAVING
 	if q.having != nil && len(q.having.Items) > 0 {
-		havingClause, havingValues, err := q.having.Build()
-		if err == nil && havingClause != "" {
+		havingClause, havingValues, err := q.having.BuildFor(query)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("having: %w", err))
+		} else if havingClause != "" {
 			query = query.Having(havingClause, havingValues...)
 		}
 	}
@@ -262,7 +533,7 @@ func (q *QueryBuilder[T]) buildQuery() *gorm.DB {
 		query = query.Preload(preload)
 	}
 
-	return query
+	return query, hasWhere
 }
 
 // Find executes the query and returns all matching records
@@ -305,19 +576,76 @@ func (q *QueryBuilder[T]) Exists() (bool, error) {
 	return count > 0, err
 }
 
-// Delete executes the query and deletes all matching records
+// Delete executes the query and deletes all matching records, which is a soft delete
+// if T is soft deletable, also of WithTrashed and OnlyTrashed queries, see ForceDelete;
+// it returns ErrMissingWhereClause if the query has no WHERE, unless AllowGlobal
 func (q *QueryBuilder[T]) Delete() error {
-	return q.buildQuery().Delete(q.model).Error
+	return q.buildWriteQuery(softDelete).Delete(q.model).Error
+}
+
+// ForceDelete executes the query and deletes all matching records permanently,
+// including the soft deleted ones of WithTrashed and OnlyTrashed queries;
+// it returns ErrMissingWhereClause if the query has no WHERE, unless AllowGlobal
+func (q *QueryBuilder[T]) ForceDelete() error {
+	return q.buildWriteQuery(forceDelete).Delete(q.model).Error
 }
 
-// Update executes the query and updates all matching records
+// Update executes the query and updates all matching records.
+// If T is versioned, see OptimisticLock, the query must check the version, e.g. by UpdateVersioned,
+// or ErrVersionRequired is returned; the version is incremented, and ErrStaleObject is returned
+// if no records match. It returns ErrMissingWhereClause if the query has no WHERE, unless AllowGlobal.
 func (q *QueryBuilder[T]) Update(updates map[string]interface{}) error {
-	return q.buildQuery().Updates(updates).Error
+	return q.update(updates, false)
 }
 
-// UpdateColumn executes the query and updates specific columns
+// update updates the matching records, by UpdateColumns without the hooks and the update time if columns,
+// with the version checked and incremented if T is versioned
+func (q *QueryBuilder[T]) update(updates map[string]interface{}, columns bool) error {
+	isVersionChecked := false
+	if isVersioned[T]() {
+		if _, ok := q.where.Get(VersionColumn); !ok {
+			return ErrVersionRequired
+		}
+
+		isVersionChecked = true
+	}
+
+	query := q.buildWriteQuery(noDelete)
+
+	var result *gorm.DB
+	if columns {
+		result = query.UpdateColumns(versionedUpdates[T](updates))
+	} else {
+		result = query.Updates(versionedUpdates[T](updates))
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 && isVersionChecked {
+		return ErrStaleObject
+	}
+
+	return nil
+}
+
+// UpdateVersioned executes the query and updates the matching records of the version,
+// which is the version read, see OptimisticLock, and returns ErrStaleObject if no records match
+func (q *QueryBuilder[T]) UpdateVersioned(version int64, updates map[string]interface{}) error {
+	return q.Clone().Where(VersionColumn, version).Update(updates)
+}
+
+// UpdateColumn executes the query and updates specific columns without the hooks and the update time.
+// If T is versioned, the version is checked and incremented like Update, see UpdateColumnVersioned.
+// It returns ErrMissingWhereClause if the query has no WHERE, unless AllowGlobal
 func (q *QueryBuilder[T]) UpdateColumn(updates map[string]interface{}) error {
-	return q.buildQuery().UpdateColumns(updates).Error
+	return q.update(updates, true)
+}
+
+// UpdateColumnVersioned executes the query and updates specific columns of the matching records
+// of the version, like UpdateVersioned
+func (q *QueryBuilder[T]) UpdateColumnVersioned(version int64, updates map[string]interface{}) error {
+	return q.Clone().Where(VersionColumn, version).UpdateColumn(updates)
 }
 
 // Scan executes the query and scans the result into the provided destination
@@ -327,35 +655,67 @@ func (q *QueryBuilder[T]) Scan(dest interface{}) error {
 
 // Raw executes a raw SQL query and returns the result
 func (q *QueryBuilder[T]) Raw(sql string, values ...interface{}) *QueryBuilder[T] {
+	q = q.mutate()
 	q.db = q.db.Raw(sql, values...)
 	return q
 }
 
-// Clone creates a copy of the query builder
+// Clone creates a deep copy of the query builder, changing the copy does not affect the original
 func (q *QueryBuilder[T]) Clone() *QueryBuilder[T] {
 	clone := &QueryBuilder[T]{
-		db:       q.db,
-		model:    q.model,
-		where:    q.where,
-		selects:  make([]string, len(q.selects)),
-		orders:   q.orders,
-		joins:    make([]JoinClause, len(q.joins)),
-		preloads: make([]string, len(q.preloads)),
-		limit:    q.limit,
-		offset:   q.offset,
-		group:    make([]string, len(q.group)),
-		having:   q.having,
-		distinct: q.distinct,
+		db:          q.db,
+		model:       q.model,
+		where:       q.where.Clone(),
+		selects:     make([]string, len(q.selects)),
+		orders:      q.orders.Clone(),
+		joins:       make([]JoinClause, len(q.joins)),
+		preloads:    make([]string, len(q.preloads)),
+		group:       make([]string, len(q.group)),
+		having:      q.having.Clone(),
+		distinct:    q.distinct,
+		trashed:     q.trashed,
+		immutable:   q.immutable,
+		allowGlobal: q.allowGlobal,
+	}
+
+	if q.limit != nil {
+		limit := *q.limit
+		clone.limit = &limit
+	}
+	if q.offset != nil {
+		offset := *q.offset
+		clone.offset = &offset
 	}
 
 	copy(clone.selects, q.selects)
-	copy(clone.joins, q.joins)
 	copy(clone.preloads, q.preloads)
 	copy(clone.group, q.group)
+	for i, join := range q.joins {
+		join.Args = append([]interface{}(nil), join.Args...)
+		clone.joins[i] = join
+	}
 
 	return clone
 }
 
+// Immutable returns a copy of the query builder whose chain methods return a new builder
+// instead of changing it, so a base query can be stored, e.g. as a package-level value,
+// and reused to build other queries across goroutines
+func (q *QueryBuilder[T]) Immutable() *QueryBuilder[T] {
+	clone := q.Clone()
+	clone.immutable = true
+	return clone
+}
+
+// mutate returns the builder to change by a chain method, a copy if the builder is immutable
+func (q *QueryBuilder[T]) mutate() *QueryBuilder[T] {
+	if q.immutable {
+		return q.Clone()
+	}
+
+	return q
+}
+
 // GetTableName returns the table name for the model
 func (q *QueryBuilder[T]) GetTableName() string {
 	var model T
@@ -377,7 +737,7 @@ func (q *QueryBuilder[T]) GetTableName() string {
 func (q *QueryBuilder[T]) ToSQL() (string, error) {
 	query := q.buildQuery()
 	stmt := query.Statement
-	return stmt.SQL.String(), nil
+	return stmt.SQL.String(), query.Error
 }
 
 // Aggregate Methods
@@ -451,7 +811,7 @@ func (q *QueryBuilder[T]) Create(value *T) error {
 
 // Save saves the record (insert if not exists, update if exists)
 func (q *QueryBuilder[T]) Save(value *T) error {
-	return q.db.Save(value).Error
+	return saveOne(q.db, value)
 }
 
 // CreateInBatches inserts records in batches
@@ -474,9 +834,8 @@ func (q *QueryBuilder[T]) Paginate(page, pageSize int) ([]*T, int64, error) {
 		return nil, 0, err
 	}
 
-	// Get paginated results
-	q.Page(page, pageSize)
-	results, err := q.Find()
+	// Get paginated results, without changing the builder
+	results, err := q.Clone().Page(page, pageSize).Find()
 	return results, total, err
 }
 
@@ -484,8 +843,7 @@ func (q *QueryBuilder[T]) Paginate(page, pageSize int) ([]*T, int64, error) {
 func (q *QueryBuilder[T]) Chunk(chunkSize int, callback func([]*T) error) error {
 	offset := 0
 	for {
-		query := q.Clone()
-		query.Limit(chunkSize).Offset(offset)
+		query := q.Clone().Limit(chunkSize).Offset(offset)
 
 		results, err := query.Find()
 		if err != nil {
@@ -1,6 +1,7 @@
 package gormx
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -492,6 +493,77 @@ func TestQueryBuilder_Clone(t *testing.T) {
 	})
 }
 
+func TestQueryBuilder_Immutable(t *testing.T) {
+	openTestDB(t, "test_chain_immutable", &TestChainProduct{})
+	db := GetNamedDB("test_chain_immutable")
+	for _, name := range []string{"Laptop", "Phone", "Monitor"} {
+		db.Create(&TestChainProduct{Name: name, Category: "Electronics", InStock: name != "Monitor"})
+	}
+	db.Create(&TestChainProduct{Name: "Book", Category: "Books", InStock: true})
+
+	t.Run("Deep Clone", func(t *testing.T) {
+		original := NewQueryOn[TestChainProduct]("test_chain_immutable").
+			WhereGroup(func(w *Where) { w.Set("category", "Electronics") }).
+			OrderByAsc("name")
+
+		clone := original.Clone()
+		clone.where.Items[0].Group.Set("in_stock", true)
+		clone.OrderByDesc("price")
+
+		if len(original.where.Items[0].Group.Items) != 1 || len(*original.orders) != 1 {
+			t.Errorf("Expected the original unchanged by the clone")
+		}
+
+		originalCount, _ := original.Count()
+		cloneCount, _ := clone.Count()
+		if originalCount != 3 || cloneCount != 2 {
+			t.Errorf("Expected 3 original and 2 cloned, got %d and %d", originalCount, cloneCount)
+		}
+	})
+
+	t.Run("Immutable", func(t *testing.T) {
+		base := NewQueryOn[TestChainProduct]("test_chain_immutable").
+			UsePrimary().
+			Immutable().
+			Where("category", "Electronics")
+
+		inStock := base.Where("in_stock", true)
+
+		if count, _ := base.Count(); count != 3 {
+			t.Errorf("Expected 3 of the base, got %d", count)
+		}
+		if count, _ := inStock.Count(); count != 2 {
+			t.Errorf("Expected 2 in stock, got %d", count)
+		}
+
+		results, total, err := base.Paginate(1, 2)
+		if err != nil || len(results) != 2 || total != 3 {
+			t.Errorf("Expected 2 of 3 paginated, got %d of %d %v", len(results), total, err)
+		}
+		if base.limit != nil {
+			t.Errorf("Expected Paginate not to change the base")
+		}
+
+		chunks := 0
+		if err := base.Chunk(2, func([]*TestChainProduct) error { chunks++; return nil }); err != nil || chunks != 2 {
+			t.Errorf("Expected 2 chunks, got %d %v", chunks, err)
+		}
+
+		done := make(chan int64)
+		for i := 0; i < 4; i++ {
+			go func() {
+				count, _ := base.WhereNotEqual("name", "Laptop").Count()
+				done <- count
+			}()
+		}
+		for i := 0; i < 4; i++ {
+			if count := <-done; count != 2 {
+				t.Errorf("Expected 2 of the concurrent query, got %d", count)
+			}
+		}
+	})
+}
+
 func TestQueryBuilder_ChainedOperations(t *testing.T) {
 	setupChainTestData(t)
 	defer cleanupChainTestData(t)
@@ -518,3 +590,111 @@ func TestQueryBuilder_ChainedOperations(t *testing.T) {
 		}
 	})
 }
+
+func TestQueryBuilder_BuildErrors(t *testing.T) {
+	openTestDB(t, "test_chain_build_errors", &TestChainProduct{})
+	db := GetNamedDB("test_chain_build_errors")
+	for _, name := range []string{"Laptop", "Phone", "Book"} {
+		db.Create(&TestChainProduct{Name: name, Price: 10})
+	}
+
+	query := func() *QueryBuilder[TestChainProduct] {
+		return NewQueryOn[TestChainProduct]("test_chain_build_errors")
+	}
+
+	t.Run("Invalid Where", func(t *testing.T) {
+		// the full text search keyword must be a string
+		invalid := query().Search("", "name").Where("q", 123).OrderByRank()
+
+		var buildErr *QueryBuildError
+		if _, err := invalid.Find(); !errors.As(err, &buildErr) || len(buildErr.Errors) != 2 {
+			t.Errorf("Expected the where and order by errors of Find, got %v", err)
+		}
+		if _, err := invalid.Count(); !errors.As(err, &buildErr) {
+			t.Errorf("Expected a build error of Count, got %v", err)
+		}
+		if _, err := invalid.Sum("price"); !errors.As(err, &buildErr) {
+			t.Errorf("Expected a build error of Sum, got %v", err)
+		}
+
+		if err := invalid.Delete(); !errors.As(err, &buildErr) {
+			t.Errorf("Expected a build error of Delete, got %v", err)
+		}
+		if count, _ := query().Count(); count != 3 {
+			t.Errorf("Expected nothing deleted, got %d records", count)
+		}
+	})
+
+	t.Run("Unwrap", func(t *testing.T) {
+		err := error(&QueryBuildError{Errors: []error{
+			ErrInvalidFieldValue,
+			&UnknownFieldError{Field: "secret", Usage: "filter"},
+		}})
+
+		if !errors.Is(err, ErrInvalidFieldValue) || !errors.Is(err, ErrUnknownField) {
+			t.Errorf("Expected every error matched by errors.Is, got %v", err)
+		}
+
+		var unknownErr *UnknownFieldError
+		if !errors.As(err, &unknownErr) || unknownErr.Field != "secret" {
+			t.Errorf("Expected the UnknownFieldError matched by errors.As, got %v", err)
+		}
+	})
+
+	t.Run("Global Write", func(t *testing.T) {
+		if err := query().Update(map[string]interface{}{"price": 20}); !errors.Is(err, ErrMissingWhereClause) {
+			t.Errorf("Expected ErrMissingWhereClause of Update, got %v", err)
+		}
+		if err := query().UpdateColumn(map[string]interface{}{"price": 20}); !errors.Is(err, ErrMissingWhereClause) {
+			t.Errorf("Expected ErrMissingWhereClause of UpdateColumn, got %v", err)
+		}
+		if err := query().OnlyTrashed().Delete(); !errors.Is(err, ErrMissingWhereClause) {
+			t.Errorf("Expected ErrMissingWhereClause of Delete, got %v", err)
+		}
+		if err := query().Join("test_chain_products AS p", "p.id = test_chain_products.id").Delete(); !errors.Is(err, ErrMissingWhereClause) {
+			t.Errorf("Expected ErrMissingWhereClause of a joined Delete, got %v", err)
+		}
+
+		if err := query().AllowGlobal().Update(map[string]interface{}{"price": 20}); err != nil {
+			t.Fatalf("Expected the global update allowed, got %v", err)
+		}
+		if sum, _ := query().Sum("price"); sum != 60 {
+			t.Errorf("Expected all prices updated, got sum %f", sum)
+		}
+
+		if err := query().AllowGlobal().Delete(); err != nil {
+			t.Fatalf("Expected the global delete allowed, got %v", err)
+		}
+		if count, _ := query().Count(); count != 0 {
+			t.Errorf("Expected all deleted, got %d records", count)
+		}
+
+		// the soft deleted records are purged without AllowGlobal
+		if err := query().OnlyTrashed().ForceDelete(); err != nil {
+			t.Fatalf("Expected the trashed purge allowed, got %v", err)
+		}
+		if count, _ := query().WithTrashed().Count(); count != 0 {
+			t.Errorf("Expected all purged, got %d records", count)
+		}
+	})
+}
+
+func TestQueryBuilder_OperatorHelpers(t *testing.T) {
+	q := newQuery[TestChainProduct](nil).
+		WhereGte("price", 10).
+		WhereBetween("price", 5, 100).
+		WhereStartsWith("name", "La").
+		WhereEndsWith("name", "op").
+		WhereEqual("category", "Books").
+		WhereEqual("category", "Electronics")
+
+	query, args, err := q.where.Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	// the range and prefix conditions on the same field are combined, WhereEqual replaces
+	if len(q.where.Items) != 5 || len(args) != 6 || args[5] != "Electronics" {
+		t.Errorf("Expected 5 conditions with 6 args, got %s %v", query, args)
+	}
+}
@@ -0,0 +1,183 @@
+package gormx
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm/schema"
+)
+
+// EnumModel is implemented by the models that declare the allowed values of their enum columns.
+type EnumModel interface {
+	FieldEnums() map[string][]string
+}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12}$`)
+
+// dateLayouts is the accepted layouts of time values.
+var dateLayouts = []string{time.RFC3339Nano, time.RFC3339, "2006-01-02 15:04:05", "2006-01-02"}
+
+// valueCoercer converts the query string filter values to the field types of the model schema.
+type valueCoercer struct {
+	fields map[string]*schema.Field
+	enums  map[string][]string
+}
+
+func newValueCoercer(model any) (*valueCoercer, error) {
+	s, err := ParseSchema(model)
+	if err != nil {
+		return nil, fmt.Errorf("parse model schema failed: %s", err)
+	}
+
+	c := &valueCoercer{
+		fields: map[string]*schema.Field{},
+	}
+	for _, field := range s.Fields {
+		if field.DBName != "" {
+			c.fields[field.DBName] = field
+		}
+	}
+
+	if m, ok := model.(EnumModel); ok {
+		c.enums = m.FieldEnums()
+	}
+
+	return c, nil
+}
+
+// coerceWhere converts the values of the where in place.
+func (c *valueCoercer) coerceWhere(where *Where) error {
+	for i := range where.Items {
+		item := &where.Items[i]
+		if item.Group != nil {
+			if err := c.coerceWhere(item.Group); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// keep the patterns of LIKE as strings
+		if item.Key == "q" || item.IsPlain || item.IsFullTextSearch || item.IsFuzzy || item.IsStartsWith || item.IsEndsWith ||
+			item.IsNull || item.IsNotNull {
+			continue
+		}
+
+		field, ok := c.fields[item.Key]
+		if !ok {
+			continue
+		}
+
+		value, err := c.coerceValue(field, item.Value)
+		if err != nil {
+			return err
+		}
+
+		item.Value = value
+	}
+
+	return nil
+}
+
+func (c *valueCoercer) coerceValue(field *schema.Field, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		return c.coerce(field, v)
+	case []string:
+		values := make([]interface{}, 0, len(v))
+		for _, one := range v {
+			coerced, err := c.coerce(field, one)
+			if err != nil {
+				return nil, err
+			}
+
+			values = append(values, coerced)
+		}
+
+		return values, nil
+	default:
+		return value, nil
+	}
+}
+
+func (c *valueCoercer) coerce(field *schema.Field, value string) (interface{}, error) {
+	invalid := func(typ string) error {
+		return &FieldValueError{Field: field.DBName, Value: value, Type: typ}
+	}
+
+	if enums, ok := c.enums[field.DBName]; ok {
+		for _, enum := range enums {
+			if enum == value {
+				return value, nil
+			}
+		}
+
+		return nil, invalid(fmt.Sprintf("one of %s", strings.Join(enums, ", ")))
+	}
+
+	if isUUIDField(field) {
+		if !uuidPattern.MatchString(value) {
+			return nil, invalid("uuid")
+		}
+
+		return value, nil
+	}
+
+	switch field.DataType {
+	case schema.Bool:
+		v, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, invalid("bool")
+		}
+
+		return v, nil
+	case schema.Int:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, invalid("int")
+		}
+
+		return v, nil
+	case schema.Uint:
+		v, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, invalid("uint")
+		}
+
+		return v, nil
+	case schema.Float:
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, invalid("float")
+		}
+
+		return v, nil
+	case schema.Time:
+		for _, layout := range dateLayouts {
+			if v, err := time.Parse(layout, value); err == nil {
+				return v, nil
+			}
+		}
+
+		return nil, invalid("time (RFC3339 or 2006-01-02)")
+	}
+
+	return value, nil
+}
+
+func isUUIDField(field *schema.Field) bool {
+	if strings.EqualFold(string(field.DataType), "uuid") {
+		return true
+	}
+
+	t := field.FieldType
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	return t.Kind() == reflect.Array && t.Len() == 16 && t.Elem().Kind() == reflect.Uint8
+}
@@ -0,0 +1,163 @@
+package gormx
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	zlogger "github.com/go-zoox/logger"
+	"gorm.io/gorm"
+)
+
+// DefaultConnection is the name of the default connection,
+// which is the one returned by GetDB.
+const DefaultConnection = "default"
+
+// connection is a named database connection.
+type connection struct {
+	db     *gorm.DB
+	engine string
+	dsn    string
+}
+
+var connections = map[string]*connection{}
+var connectionsLock = &sync.RWMutex{}
+
+type connectionContextKey struct{}
+
+// LoadNamedDB loads the database as the named connection.
+// Loading the DefaultConnection is the same as LoadDB.
+func LoadNamedDB(name string, engine string, dsn string, opts ...func(*LoadDBOptions)) error {
+	if name == DefaultConnection {
+		return LoadDB(engine, dsn, opts...)
+	}
+
+	d, err := open(engine, dsn, opts...)
+	if err != nil {
+		return fmt.Errorf("connecting database(%s) failed: %s", name, err.Error())
+	}
+
+	connectionsLock.Lock()
+	defer connectionsLock.Unlock()
+
+	connections[name] = &connection{
+		db:     d,
+		engine: engine,
+		dsn:    dsn,
+	}
+
+	return nil
+}
+
+// GetNamedDB returns the gorm.DB instance of the named connection.
+func GetNamedDB(name string) *gorm.DB {
+	if name == "" || name == DefaultConnection {
+		return GetDB()
+	}
+
+	connectionsLock.RLock()
+	defer connectionsLock.RUnlock()
+
+	c, ok := connections[name]
+	if !ok {
+		panic(fmt.Sprintf("DB(%s) is not initialized", name))
+	}
+
+	return c.db
+}
+
+// SetNamedDB sets the gorm.DB instance of the named connection.
+func SetNamedDB(name string, d *gorm.DB) {
+	if name == "" || name == DefaultConnection {
+		SetDB(d)
+		return
+	}
+
+	engine := ""
+	if d != nil && d.Dialector != nil {
+		engine = d.Dialector.Name()
+	}
+
+	if d != nil && d.Config != nil {
+		if err := registerCallbacks(d); err != nil {
+			zlogger.Warnf("[gormx][set_named_db] registering callbacks failed: %s", err)
+		}
+	}
+
+	connectionsLock.Lock()
+	defer connectionsLock.Unlock()
+
+	connections[name] = &connection{
+		db:     d,
+		engine: engine,
+	}
+}
+
+// HasNamedDB returns true if the named connection is loaded.
+func HasNamedDB(name string) bool {
+	if name == "" || name == DefaultConnection {
+		return db != nil
+	}
+
+	connectionsLock.RLock()
+	defer connectionsLock.RUnlock()
+
+	_, ok := connections[name]
+	return ok
+}
+
+// GetNamedEngine returns the database engine of the named connection.
+func GetNamedEngine(name string) string {
+	if name == "" || name == DefaultConnection {
+		return GetEngine()
+	}
+
+	connectionsLock.RLock()
+	defer connectionsLock.RUnlock()
+
+	if c, ok := connections[name]; ok {
+		return c.engine
+	}
+
+	return ""
+}
+
+// GetNamedDSN returns the database DSN of the named connection.
+func GetNamedDSN(name string) string {
+	if name == "" || name == DefaultConnection {
+		return GetDSN()
+	}
+
+	connectionsLock.RLock()
+	defer connectionsLock.RUnlock()
+
+	if c, ok := connections[name]; ok {
+		return c.dsn
+	}
+
+	return ""
+}
+
+// WithConnection returns a copy of ctx that pins the ...Ctx helpers
+// to the named connection.
+func WithConnection(ctx context.Context, name string) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithValue(ctx, connectionContextKey{}, name)
+}
+
+// ConnectionFromContext returns the connection name pinned in ctx,
+// or DefaultConnection if there is none.
+func ConnectionFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return DefaultConnection
+	}
+
+	if name, ok := ctx.Value(connectionContextKey{}).(string); ok && name != "" {
+		return name
+	}
+
+	return DefaultConnection
+}
@@ -0,0 +1,69 @@
+package gormx
+
+import (
+	"context"
+	"testing"
+)
+
+// openTestDB loads an in-memory SQLite database as the named connection, migrates the models,
+// and returns a context pinned to the connection. The single connection keeps the database,
+// which is per connection in memory.
+func openTestDB(t *testing.T, name string, models ...any) context.Context {
+	t.Helper()
+
+	err := LoadNamedDB(name, "sqlite", ":memory:", func(opt *LoadDBOptions) {
+		opt.IsProd = true
+		opt.MaxOpenConns = 1
+	})
+	if err != nil {
+		t.Fatalf("LoadNamedDB failed: %v", err)
+	}
+
+	ctx := WithConnection(context.Background(), name)
+	if len(models) > 0 {
+		if err := GetDBWithContext(ctx).AutoMigrate(models...); err != nil {
+			t.Fatalf("AutoMigrate failed: %v", err)
+		}
+	}
+
+	return ctx
+}
+
+func TestNamedConnection(t *testing.T) {
+	openTestDB(t, "test_cache")
+
+	if !HasNamedDB("test_cache") {
+		t.Fatal("Expected named connection test_cache to be loaded")
+	}
+
+	if HasNamedDB("test_missing") {
+		t.Error("Expected named connection test_missing not to be loaded")
+	}
+
+	if engine := GetNamedEngine("test_cache"); engine != "sqlite" {
+		t.Errorf("Expected engine sqlite, got %s", engine)
+	}
+
+	ctx := WithConnection(context.Background(), "test_cache")
+	if name := ConnectionFromContext(ctx); name != "test_cache" {
+		t.Errorf("Expected connection test_cache, got %s", name)
+	}
+
+	if name := ConnectionFromContext(context.Background()); name != DefaultConnection {
+		t.Errorf("Expected default connection, got %s", name)
+	}
+
+	if GetDBWithContext(ctx).ConnPool != GetNamedDB("test_cache").ConnPool {
+		t.Error("Expected GetDBWithContext to use the pinned connection")
+	}
+
+	t.Run("GetNamedDB panics on unknown connection", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected GetNamedDB to panic")
+			}
+		}()
+
+		GetNamedDB("test_missing")
+	})
+}
@@ -1,14 +1,21 @@
 package gormx
 
+import "context"
+
 // Count counts records.
 func Count[T any](where *Where) (count int64, err error) {
-	whereClause, whereValues, errx := where.Build()
+	return CountCtx[T](context.Background(), where)
+}
+
+// CountCtx counts records with context.
+func CountCtx[T any](ctx context.Context, where *Where) (count int64, err error) {
+	countTx := GetDBWithContext(ctx).Model(new(T))
+
+	whereClause, whereValues, errx := where.BuildFor(countTx)
 	if errx != nil {
 		return 0, errx
 	}
 
-	countTx := GetDB().Model(new(T))
-
 	if whereClause != "" {
 		countTx = countTx.Where(whereClause, whereValues...)
 	}
@@ -21,7 +28,12 @@ func Count[T any](where *Where) (count int64, err error) {
 
 // CountALL counts all records.
 func CountALL[T any]() (total int64, err error) {
-	err = GetDB().Model(new(T)).
+	return CountALLCtx[T](context.Background())
+}
+
+// CountALLCtx counts all records with context.
+func CountALLCtx[T any](ctx context.Context) (total int64, err error) {
+	err = GetDBWithContext(ctx).Model(new(T)).
 		Count(&total).
 		Error
 	return
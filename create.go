@@ -1,8 +1,15 @@
 package gormx
 
+import "context"
+
 // Create creates a record.
 func Create[T any](one *T) (*T, error) {
-	err := GetDB().
+	return CreateCtx(context.Background(), one)
+}
+
+// CreateCtx creates a record with context.
+func CreateCtx[T any](ctx context.Context, one *T) (*T, error) {
+	err := GetDBWithContext(ctx).
 		Create(one).Error
 
 	return one, err
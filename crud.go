@@ -0,0 +1,383 @@
+package gormx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+
+	"github.com/go-zoox/zoox"
+	"gorm.io/gorm/schema"
+)
+
+// CRUDRoute is a route mounted by RegisterCRUD.
+type CRUDRoute string
+
+const (
+	// CRUDList is GET /
+	CRUDList CRUDRoute = "list"
+	// CRUDRetrieve is GET /:id
+	CRUDRetrieve CRUDRoute = "retrieve"
+	// CRUDCreate is POST /
+	CRUDCreate CRUDRoute = "create"
+	// CRUDUpdate is PUT /:id, which replaces the record, and PATCH /:id, which merges into it
+	CRUDUpdate CRUDRoute = "update"
+	// CRUDDelete is DELETE /:id
+	CRUDDelete CRUDRoute = "delete"
+)
+
+// CRUDRouter is the router of RegisterCRUD, e.g. *zoox.Application or *zoox.RouterGroup.
+type CRUDRouter interface {
+	Get(path string, handler ...zoox.HandlerFunc) *zoox.RouterGroup
+	Post(path string, handler ...zoox.HandlerFunc) *zoox.RouterGroup
+	Put(path string, handler ...zoox.HandlerFunc) *zoox.RouterGroup
+	Patch(path string, handler ...zoox.HandlerFunc) *zoox.RouterGroup
+	Delete(path string, handler ...zoox.HandlerFunc) *zoox.RouterGroup
+}
+
+// CRUDOptions is the options for RegisterCRUD.
+type CRUDOptions[T any] struct {
+	// Model is the generic model of the routes, e.g. pinned to a connection by UseConnection.
+	Model *ModelGeneric[T]
+
+	// Authorize is called before every route, a non-nil error rejects the request
+	// with 403 Forbidden, or the status of the error if it has a Status() int method.
+	Authorize func(ctx *zoox.Context, route CRUDRoute) error
+
+	// Bind maps the request body to the record of create and update, which is
+	// the existing record on PATCH, default is decoding the JSON body into it.
+	// The primary key and the audit fields cannot be changed by Bind.
+	Bind func(ctx *zoox.Context, route CRUDRoute, one *T) error
+
+	// Mask maps the record to the response, e.g. to hide the sensitive fields,
+	// default is the record itself.
+	Mask func(ctx *zoox.Context, one *T) any
+
+	// Filterable is the fields allowed in the query string filters of the list route,
+	// default is the fields of T, see Params.UseModel, or none if there is a Mask.
+	Filterable []string
+
+	// Sortable is the fields allowed in the query string order by of the list route,
+	// default is the fields of T, see Params.UseModel, or none if there is a Mask.
+	Sortable []string
+
+	// Disabled is the routes not to mount.
+	Disabled []CRUDRoute
+
+	// Pagination is the pagination policy of the list route.
+	Pagination *PaginationPolicy
+}
+
+// RegisterCRUD mounts the REST routes of the model T under the prefix:
+//
+//	GET    {prefix}       list, see Params.GetList
+//	GET    {prefix}/:id   retrieve
+//	POST   {prefix}       create
+//	PUT    {prefix}/:id   update, replacing the record
+//	PATCH  {prefix}/:id   update, merging into the record
+//	DELETE {prefix}/:id   delete
+//
+// The query string filters and order by of the list route are limited to the
+// fields of T, see Params.UseModel, or to the Filterable and Sortable options,
+// which are required to filter or sort if there is a Mask.
+func RegisterCRUD[T any](router CRUDRouter, prefix string, opts ...func(*CRUDOptions[T])) {
+	opt := &CRUDOptions[T]{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	c := &crud[T]{opt: opt}
+
+	if c.isEnabled(CRUDList) {
+		router.Get(prefix, c.handle(CRUDList, c.list))
+	}
+	if c.isEnabled(CRUDRetrieve) {
+		router.Get(prefix+"/:id", c.handle(CRUDRetrieve, c.retrieve))
+	}
+	if c.isEnabled(CRUDCreate) {
+		router.Post(prefix, c.handle(CRUDCreate, c.create))
+	}
+	if c.isEnabled(CRUDUpdate) {
+		router.Put(prefix+"/:id", c.handle(CRUDUpdate, c.replace))
+		router.Patch(prefix+"/:id", c.handle(CRUDUpdate, c.update))
+	}
+	if c.isEnabled(CRUDDelete) {
+		router.Delete(prefix+"/:id", c.handle(CRUDDelete, c.delete))
+	}
+}
+
+// crud is the handlers of RegisterCRUD.
+type crud[T any] struct {
+	opt *CRUDOptions[T]
+}
+
+// crudError is the error of a CRUD request with the response status.
+type crudError struct {
+	status int
+	err    error
+}
+
+func (e *crudError) Error() string {
+	return e.err.Error()
+}
+
+func (c *crud[T]) isEnabled(route CRUDRoute) bool {
+	for _, disabled := range c.opt.Disabled {
+		if disabled == route {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c *crud[T]) model(ctx *zoox.Context) *ModelGeneric[T] {
+	return c.opt.Model.WithContext(NewParams(ctx).Context())
+}
+
+func (c *crud[T]) handle(route CRUDRoute, handler func(ctx *zoox.Context) (any, error)) zoox.HandlerFunc {
+	return func(ctx *zoox.Context) {
+		if c.opt.Authorize != nil {
+			if err := c.opt.Authorize(ctx, route); err != nil {
+				c.fail(ctx, err, http.StatusForbidden)
+				return
+			}
+		}
+
+		result, err := handler(ctx)
+		if err != nil {
+			var crudErr *crudError
+			if errors.As(err, &crudErr) {
+				c.fail(ctx, crudErr.err, crudErr.status)
+			} else {
+				c.fail(ctx, err, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		ctx.Success(result)
+	}
+}
+
+// fail responds the error, with the status of the error if any, or the fallback status.
+func (c *crud[T]) fail(ctx *zoox.Context, err error, status int) {
+	var statusErr interface{ Status() int }
+	if errors.As(err, &statusErr) {
+		status = statusErr.Status()
+	} else if IsRecordNotFoundError(err) {
+		status = http.StatusNotFound
+	}
+
+	message := err.Error()
+	if status >= http.StatusInternalServerError {
+		// do not leak the database errors
+		message = http.StatusText(status)
+	}
+
+	ctx.Fail(err, status, message, status)
+}
+
+func (c *crud[T]) mask(ctx *zoox.Context, one *T) any {
+	if c.opt.Mask == nil {
+		return one
+	}
+
+	return c.opt.Mask(ctx, one)
+}
+
+func (c *crud[T]) bind(ctx *zoox.Context, route CRUDRoute, one *T) error {
+	var err error
+	if c.opt.Bind != nil {
+		err = c.opt.Bind(ctx, route, one)
+	} else {
+		err = ctx.BindJSON(one)
+	}
+	if err != nil {
+		return &crudError{status: http.StatusBadRequest, err: err}
+	}
+
+	return nil
+}
+
+func (c *crud[T]) id(ctx *zoox.Context) (uint, error) {
+	id, err := NewParams(ctx).ID()
+	if err != nil {
+		return 0, &crudError{status: http.StatusBadRequest, err: err}
+	}
+
+	return id, nil
+}
+
+// protectFields restores the primary key, the audit fields, the create time and the soft delete
+// of the record from the original, which cannot be changed by the request body, the original
+// of create is zero. The version is restored on create, or if it is not given on update.
+func protectFields[T any](ctx context.Context, one *T, original *T, isCreate bool) error {
+	s, err := ParseSchema(one)
+	if err != nil {
+		return err
+	}
+
+	fields := []*schema.Field{s.PrioritizedPrimaryField, s.LookUpField(CreatorField), s.LookUpField(ModifierField)}
+	for _, field := range s.Fields {
+		if field.AutoCreateTime != 0 || field.FieldType == deletedAtType {
+			fields = append(fields, field)
+		}
+	}
+
+	if version := s.LookUpField(VersionColumn); version != nil {
+		if _, isZero := version.ValueOf(ctx, reflect.ValueOf(one)); isZero || isCreate {
+			fields = append(fields, version)
+		}
+	}
+
+	for _, field := range fields {
+		if field == nil {
+			continue
+		}
+
+		value, _ := field.ValueOf(ctx, reflect.ValueOf(original))
+		if err := field.Set(ctx, reflect.ValueOf(one), value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *crud[T]) list(ctx *zoox.Context) (any, error) {
+	params := NewParams(ctx).UseModel(new(T)).UsePagination(c.opt.Pagination)
+
+	filterable, sortable := c.opt.Filterable, c.opt.Sortable
+	// the fields hidden by Mask are unknown, only the explicit ones are allowed
+	if c.opt.Mask != nil {
+		if filterable == nil {
+			filterable = []string{}
+		}
+		if sortable == nil {
+			sortable = []string{}
+		}
+	}
+	if filterable != nil {
+		params.UseFilterable(filterable...)
+	}
+	if sortable != nil {
+		params.UseSortable(sortable...)
+	}
+
+	listParams, err := params.GetList()
+	if err != nil {
+		return nil, &crudError{status: http.StatusBadRequest, err: err}
+	}
+
+	result, err := ListByParamsCtx[T](c.model(ctx).getContext(), listParams)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.opt.Mask == nil {
+		return result, nil
+	}
+
+	data := make([]*any, 0, len(result.Data))
+	for _, one := range result.Data {
+		masked := c.mask(ctx, one)
+		data = append(data, &masked)
+	}
+
+	return &ListResult[any]{
+		Data:     data,
+		Total:    result.Total,
+		Page:     result.Page,
+		PageSize: result.PageSize,
+		HasNext:  result.HasNext,
+	}, nil
+}
+
+func (c *crud[T]) retrieve(ctx *zoox.Context) (any, error) {
+	id, err := c.id(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	one, err := c.model(ctx).Retrieve(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.mask(ctx, one), nil
+}
+
+func (c *crud[T]) create(ctx *zoox.Context) (any, error) {
+	one := new(T)
+	if err := c.bind(ctx, CRUDCreate, one); err != nil {
+		return nil, err
+	}
+
+	// the primary key is generated by the database
+	if err := protectFields(ctx.Context(), one, new(T), true); err != nil {
+		return nil, err
+	}
+
+	one, err := c.model(ctx).Create(one)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.mask(ctx, one), nil
+}
+
+func (c *crud[T]) update(ctx *zoox.Context) (any, error) {
+	return c.save(ctx, false)
+}
+
+func (c *crud[T]) replace(ctx *zoox.Context) (any, error) {
+	return c.save(ctx, true)
+}
+
+// save updates the record by the request body, which replaces the record,
+// or is merged into the existing record.
+func (c *crud[T]) save(ctx *zoox.Context, isReplace bool) (any, error) {
+	id, err := c.id(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	reqCtx := forWrite(c.model(ctx).getContext())
+
+	one, err := RetrieveCtx[T](reqCtx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	original := *one
+	if isReplace {
+		one = new(T)
+	}
+
+	if err := c.bind(ctx, CRUDUpdate, one); err != nil {
+		return nil, err
+	}
+
+	if err := protectFields(reqCtx, one, &original, false); err != nil {
+		return nil, err
+	}
+
+	if err := SaveCtx(reqCtx, one); err != nil {
+		return nil, err
+	}
+
+	return c.mask(ctx, one), nil
+}
+
+func (c *crud[T]) delete(ctx *zoox.Context) (any, error) {
+	id, err := c.id(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.model(ctx).Delete(id); err != nil {
+		return nil, err
+	}
+
+	return nil, nil
+}
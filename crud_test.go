@@ -0,0 +1,195 @@
+package gormx
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-zoox/zoox"
+	"gorm.io/gorm"
+)
+
+type TestCRUDItem struct {
+	ID     uint   `gorm:"primarykey" json:"id"`
+	Name   string `gorm:"column:name" json:"name"`
+	Secret string `gorm:"column:secret" json:"secret"`
+}
+
+type TestCRUDTrashedItem struct {
+	ID        uint           `gorm:"primarykey" json:"id"`
+	Name      string         `gorm:"column:name" json:"name"`
+	CreatedAt time.Time      `json:"created_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at"`
+}
+
+func TestRegisterCRUD(t *testing.T) {
+	openTestDB(t, "test_crud", &TestCRUDItem{})
+	m := (&ModelGeneric[TestCRUDItem]{}).UseConnection("test_crud")
+
+	app := zoox.New()
+	RegisterCRUD[TestCRUDItem](app, "/items", func(opt *CRUDOptions[TestCRUDItem]) {
+		opt.Model = m
+		opt.Disabled = []CRUDRoute{CRUDDelete}
+		opt.Authorize = func(ctx *zoox.Context, route CRUDRoute) error {
+			if ctx.Header().Get("X-Role") != "admin" && route != CRUDList && route != CRUDRetrieve {
+				return errors.New("admin only")
+			}
+			return nil
+		}
+		opt.Mask = func(ctx *zoox.Context, one *TestCRUDItem) any {
+			masked := *one
+			masked.Secret = ""
+			return &masked
+		}
+		opt.Filterable = []string{"id", "name"}
+		opt.Sortable = []string{"id", "name"}
+	})
+
+	request := func(method, path, body string, admin bool) (int, map[string]any) {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		if admin {
+			req.Header.Set("X-Role", "admin")
+		}
+
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		var response map[string]any
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w.Code, response
+	}
+
+	if code, _ := request("POST", "/items", `{"name":"foo","secret":"s"}`, false); code != http.StatusForbidden {
+		t.Errorf("Expected create to be forbidden, got %d", code)
+	}
+
+	code, response := request("POST", "/items", `{"id":100,"name":"foo","secret":"s"}`, true)
+	if code != http.StatusOK {
+		t.Fatalf("Expected create to succeed, got %d %v", code, response)
+	}
+	created := response["result"].(map[string]any)
+	if created["id"] == float64(100) || created["secret"] != "" {
+		t.Errorf("Expected generated id and masked secret, got %v", created)
+	}
+
+	code, response = request("PATCH", "/items/1", `{"id":2,"name":"bar"}`, true)
+	if code != http.StatusOK || response["result"].(map[string]any)["name"] != "bar" {
+		t.Errorf("Expected update to succeed, got %d %v", code, response)
+	}
+
+	one, err := m.Retrieve(1)
+	if err != nil || one.Name != "bar" || one.Secret != "s" {
+		t.Errorf("Expected the record to be updated in place, got %+v %v", one, err)
+	}
+
+	code, response = request("GET", "/items?name=bar", "", false)
+	if code != http.StatusOK || response["result"].(map[string]any)["total"] != float64(1) {
+		t.Errorf("Expected list to return 1 record, got %d %v", code, response)
+	}
+
+	if code, _ := request("GET", "/items?secret=s:*", "", false); code != http.StatusBadRequest {
+		t.Errorf("Expected the masked fields not to be filterable, got %d", code)
+	}
+
+	if code, _ := request("GET", "/items?orderBy=secret:asc", "", false); code != http.StatusBadRequest {
+		t.Errorf("Expected the masked fields not to be sortable, got %d", code)
+	}
+
+	if code, _ := request("GET", "/items?orderBy=name:asc&id=1", "", false); code != http.StatusOK {
+		t.Errorf("Expected the Filterable and Sortable fields to be allowed, got %d", code)
+	}
+
+	if code, _ := request("GET", "/items?bad%3Bkey=1", "", false); code != http.StatusBadRequest {
+		t.Errorf("Expected bad filters to be rejected, got %d", code)
+	}
+
+	code, response = request("PUT", "/items/1", `{"id":2,"name":"baz"}`, true)
+	if code != http.StatusOK || response["result"].(map[string]any)["name"] != "baz" {
+		t.Errorf("Expected replace to succeed, got %d %v", code, response)
+	}
+
+	one, err = m.Retrieve(1)
+	if err != nil || one.Name != "baz" || one.Secret != "" {
+		t.Errorf("Expected the record to be replaced, got %+v %v", one, err)
+	}
+
+	t.Run("Filterable", func(t *testing.T) {
+		RegisterCRUD[TestCRUDItem](app, "/filterable", func(opt *CRUDOptions[TestCRUDItem]) {
+			opt.Model = m
+			opt.Filterable = []string{"name"}
+			opt.Sortable = []string{}
+		})
+
+		if code, _ := request("GET", "/filterable?name=baz", "", false); code != http.StatusOK {
+			t.Errorf("Expected the filterable fields to be filterable, got %d", code)
+		}
+		if code, _ := request("GET", "/filterable?id=1", "", false); code != http.StatusBadRequest {
+			t.Errorf("Expected the other fields not to be filterable, got %d", code)
+		}
+		if code, _ := request("GET", "/filterable?orderBy=name:asc", "", false); code != http.StatusBadRequest {
+			t.Errorf("Expected no sortable fields, got %d", code)
+		}
+
+		// the fields of the model without Mask, none with Mask unless explicit
+		RegisterCRUD[TestCRUDItem](app, "/plain", func(opt *CRUDOptions[TestCRUDItem]) {
+			opt.Model = m
+		})
+		RegisterCRUD[TestCRUDItem](app, "/masked", func(opt *CRUDOptions[TestCRUDItem]) {
+			opt.Model = m
+			opt.Mask = func(ctx *zoox.Context, one *TestCRUDItem) any { return one.Name }
+		})
+
+		if code, _ := request("GET", "/plain?secret=s&orderBy=secret:asc", "", false); code != http.StatusOK {
+			t.Errorf("Expected the fields of the model to be allowed without Mask, got %d", code)
+		}
+		if code, _ := request("GET", "/masked?name=baz", "", false); code != http.StatusBadRequest {
+			t.Errorf("Expected no filterable fields with Mask, got %d", code)
+		}
+		if code, _ := request("GET", "/masked?orderBy=name:asc", "", false); code != http.StatusBadRequest {
+			t.Errorf("Expected no sortable fields with Mask, got %d", code)
+		}
+	})
+
+	t.Run("Protected Fields", func(t *testing.T) {
+		trashed := (&ModelGeneric[TestCRUDTrashedItem]{}).UseConnection("test_crud")
+		if err := GetNamedDB("test_crud").AutoMigrate(&TestCRUDTrashedItem{}); err != nil {
+			t.Fatalf("AutoMigrate failed: %v", err)
+		}
+		RegisterCRUD[TestCRUDTrashedItem](app, "/trashed", func(opt *CRUDOptions[TestCRUDTrashedItem]) {
+			opt.Model = trashed
+		})
+
+		body := `{"name":"foo","deleted_at":"2020-01-01T00:00:00Z","created_at":"1999-01-01T00:00:00Z"}`
+		if code, response := request("POST", "/trashed", body, true); code != http.StatusOK {
+			t.Fatalf("Expected create to succeed, got %d %v", code, response)
+		}
+		one, err := trashed.Retrieve(1)
+		if err != nil || one.CreatedAt.Year() == 1999 {
+			t.Fatalf("Expected a live record created now, got %+v %v", one, err)
+		}
+
+		for _, method := range []string{"PATCH", "PUT"} {
+			if code, response := request(method, "/trashed/1", body, true); code != http.StatusOK {
+				t.Fatalf("Expected %s to succeed, got %d %v", method, code, response)
+			}
+			updated, err := trashed.Retrieve(1)
+			if err != nil || !updated.CreatedAt.Equal(one.CreatedAt) {
+				t.Errorf("Expected %s not to delete or back-date the record, got %+v %v", method, updated, err)
+			}
+		}
+	})
+
+	if code, _ := request("GET", "/items/404", "", false); code != http.StatusNotFound {
+		t.Errorf("Expected retrieve to return 404, got %d", code)
+	}
+
+	if code, _ := request("DELETE", "/items/1", "", true); code != http.StatusMethodNotAllowed && code != http.StatusNotFound {
+		t.Errorf("Expected delete to be disabled, got %d", code)
+	}
+}
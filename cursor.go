@@ -0,0 +1,310 @@
+package gormx
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+// DefaultCursorLimit is the limit of ListByCursor if not specified.
+const DefaultCursorLimit = 10
+
+var cursorSecret []byte
+var cursorSecretLock sync.RWMutex
+
+func init() {
+	cursorSecret = make([]byte, 32)
+	if _, err := rand.Read(cursorSecret); err != nil {
+		panic(fmt.Errorf("generate cursor secret failed: %s", err))
+	}
+}
+
+// SetCursorSecret sets the secret signing the cursors of ListByCursor.
+// It defaults to a random secret, so the cursors are only valid in the
+// same process, set it to share the cursors between the instances or restarts.
+func SetCursorSecret(secret []byte) {
+	cursorSecretLock.Lock()
+	defer cursorSecretLock.Unlock()
+
+	cursorSecret = secret
+}
+
+func getCursorSecret() []byte {
+	cursorSecretLock.RLock()
+	defer cursorSecretLock.RUnlock()
+
+	return cursorSecret
+}
+
+// cursorPayload is the content of a cursor, the sort keys of the boundary row.
+type cursorPayload struct {
+	// Keys is the sort keys, e.g. created_at:desc, the cursor is invalid if the order changes
+	Keys []string `json:"k"`
+	// Values is the values of the sort keys of the boundary row
+	Values []json.RawMessage `json:"v"`
+	// IsPrev => the cursor reads the page before the boundary row
+	IsPrev bool `json:"p,omitempty"`
+}
+
+func signCursor(data []byte) []byte {
+	mac := hmac.New(sha256.New, getCursorSecret())
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func encodeCursor(payload *cursorPayload) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"%s.%s",
+		base64.RawURLEncoding.EncodeToString(data),
+		base64.RawURLEncoding.EncodeToString(signCursor(data)),
+	), nil
+}
+
+func decodeCursor(cursor string) (*cursorPayload, error) {
+	parts := strings.Split(cursor, ".")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("%w: malformed", ErrInvalidCursor)
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed", ErrInvalidCursor)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil || !hmac.Equal(signature, signCursor(data)) {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidCursor)
+	}
+
+	payload := &cursorPayload{}
+	if err := json.Unmarshal(data, payload); err != nil {
+		return nil, fmt.Errorf("%w: malformed", ErrInvalidCursor)
+	}
+
+	return payload, nil
+}
+
+// cursorKey is a sort key of the keyset pagination.
+type cursorKey struct {
+	field  *schema.Field
+	isDESC bool
+}
+
+func (k *cursorKey) String() string {
+	if k.isDESC {
+		return k.field.DBName + ":desc"
+	}
+
+	return k.field.DBName + ":asc"
+}
+
+// cursorKeys returns the sort keys of the order by,
+// with the primary key as the tie-breaker.
+func cursorKeys(s *schema.Schema, orderBy *OrderBy) ([]*cursorKey, error) {
+	keys := []*cursorKey{}
+	seen := map[string]bool{}
+
+	if orderBy != nil {
+		for _, order := range *orderBy {
+			if order.Key == "" || order.Key == RankOrderKey {
+				return nil, fmt.Errorf("cursor pagination only supports ordering by columns")
+			}
+
+			field := s.LookUpField(order.Key)
+			if field == nil || field.DBName == "" {
+				return nil, fmt.Errorf("cursor pagination cannot order by %s, which is not a column of %s", order.Key, s.Name)
+			}
+
+			if seen[field.DBName] {
+				continue
+			}
+			seen[field.DBName] = true
+
+			keys = append(keys, &cursorKey{field: field, isDESC: order.IsDESC})
+		}
+	}
+
+	if s.PrioritizedPrimaryField == nil {
+		return nil, fmt.Errorf("cursor pagination requires a primary key of %s", s.Name)
+	}
+
+	if !seen[s.PrioritizedPrimaryField.DBName] {
+		keys = append(keys, &cursorKey{field: s.PrioritizedPrimaryField})
+	}
+
+	return keys, nil
+}
+
+// ListByCursor lists records by keyset pagination, which is stable and fast on deep pages.
+// The cursor is empty for the first page, or the next or prev cursor of the previous call,
+// which is empty if there is no more records in that direction.
+func ListByCursor[T any](cursor string, limit uint, where *Where, orderBy *OrderBy) (data []*T, next string, prev string, err error) {
+	return ListByCursorCtx[T](context.Background(), cursor, limit, where, orderBy)
+}
+
+// ListByCursorCtx lists records by keyset pagination with context.
+func ListByCursorCtx[T any](ctx context.Context, cursor string, limit uint, where *Where, orderBy *OrderBy) (data []*T, next string, prev string, err error) {
+	if limit == 0 {
+		limit = DefaultCursorLimit
+	}
+
+	s, err := ParseSchema(new(T))
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	keys, err := cursorKeys(s, orderBy)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	keyNames := make([]string, len(keys))
+	for i, key := range keys {
+		keyNames[i] = key.String()
+	}
+
+	var payload *cursorPayload
+	if cursor != "" {
+		if payload, err = decodeCursor(cursor); err != nil {
+			return nil, "", "", err
+		}
+
+		if strings.Join(payload.Keys, ",") != strings.Join(keyNames, ",") || len(payload.Values) != len(keys) {
+			return nil, "", "", fmt.Errorf("%w: the order by has changed", ErrInvalidCursor)
+		}
+	}
+
+	tx := GetDBWithContext(ctx).Model(new(T))
+
+	if where != nil {
+		whereClause, whereValues, err := where.BuildFor(tx)
+		if err != nil {
+			return nil, "", "", err
+		}
+		if whereClause != "" {
+			tx = tx.Where(whereClause, whereValues...)
+		}
+	}
+
+	isPrev := payload != nil && payload.IsPrev
+	if payload != nil {
+		condition, err := keysetCondition(keys, payload)
+		if err != nil {
+			return nil, "", "", err
+		}
+
+		tx = tx.Where(condition)
+	}
+
+	for _, key := range keys {
+		// read backwards for the previous page
+		tx = tx.Order(clause.OrderByColumn{Column: clause.Column{Name: key.field.DBName}, Desc: key.isDESC != isPrev})
+	}
+
+	if err = tx.Limit(int(limit) + 1).Find(&data).Error; err != nil {
+		return nil, "", "", err
+	}
+
+	hasMore := len(data) > int(limit)
+	if hasMore {
+		data = data[:limit]
+	}
+
+	if isPrev {
+		for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+			data[i], data[j] = data[j], data[i]
+		}
+	}
+
+	if len(data) == 0 {
+		return data, "", "", nil
+	}
+
+	// forwards, there are rows before if it is not the first page;
+	// backwards, there are rows after the page we came from.
+	hasNext, hasPrev := hasMore, payload != nil
+	if isPrev {
+		hasNext, hasPrev = true, hasMore
+	}
+
+	if hasNext {
+		if next, err = rowCursor(ctx, keys, keyNames, data[len(data)-1], false); err != nil {
+			return nil, "", "", err
+		}
+	}
+	if hasPrev {
+		if prev, err = rowCursor(ctx, keys, keyNames, data[0], true); err != nil {
+			return nil, "", "", err
+		}
+	}
+
+	return data, next, prev, nil
+}
+
+// keysetCondition builds (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...,
+// with < for the descending keys, and reversed for the previous page.
+func keysetCondition(keys []*cursorKey, payload *cursorPayload) (clause.Expression, error) {
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		value := reflect.New(key.field.FieldType)
+		if err := json.Unmarshal(payload.Values[i], value.Interface()); err != nil {
+			return nil, fmt.Errorf("%w: bad value of %s", ErrInvalidCursor, key.field.DBName)
+		}
+
+		values[i] = value.Elem().Interface()
+	}
+
+	ors := []clause.Expression{}
+	for i, key := range keys {
+		ands := []clause.Expression{}
+		for j := 0; j < i; j++ {
+			ands = append(ands, clause.Eq{Column: clause.Column{Name: keys[j].field.DBName}, Value: values[j]})
+		}
+
+		column := clause.Column{Name: key.field.DBName}
+		if key.isDESC != payload.IsPrev {
+			ands = append(ands, clause.Lt{Column: column, Value: values[i]})
+		} else {
+			ands = append(ands, clause.Gt{Column: column, Value: values[i]})
+		}
+
+		ors = append(ors, clause.And(ands...))
+	}
+
+	return clause.Or(ors...), nil
+}
+
+func rowCursor(ctx context.Context, keys []*cursorKey, keyNames []string, row any, isPrev bool) (string, error) {
+	payload := &cursorPayload{
+		Keys:   keyNames,
+		IsPrev: isPrev,
+	}
+
+	rv := reflect.ValueOf(row)
+	for _, key := range keys {
+		value, _ := key.field.ValueOf(ctx, rv)
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return "", fmt.Errorf("encode cursor value of %s failed: %s", key.field.DBName, err)
+		}
+
+		payload.Values = append(payload.Values, raw)
+	}
+
+	return encodeCursor(payload)
+}
@@ -0,0 +1,84 @@
+package gormx
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+type TestCursorItem struct {
+	ID    uint   `gorm:"primarykey"`
+	Name  string `gorm:"column:name"`
+	Score int    `gorm:"column:score"`
+}
+
+func TestListByCursor(t *testing.T) {
+	ctx := openTestDB(t, "test_cursor", &TestCursorItem{})
+
+	// scores repeat, so the primary key breaks the ties
+	for i := 1; i <= 7; i++ {
+		item := &TestCursorItem{Name: fmt.Sprintf("item-%d", i), Score: i / 2}
+		if err := GetDBWithContext(ctx).Create(item).Error; err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	orderBy := NewOrderBy()
+	orderBy.Set("score", true)
+
+	names := func(data []*TestCursorItem) string {
+		vs := []string{}
+		for _, one := range data {
+			vs = append(vs, one.Name)
+		}
+		return strings.Join(vs, ",")
+	}
+
+	pages := []string{}
+	cursor := ""
+	prevs := []string{}
+	for {
+		data, next, prev, err := ListByCursorCtx[TestCursorItem](ctx, cursor, 3, nil, orderBy)
+		if err != nil {
+			t.Fatalf("ListByCursor failed: %v", err)
+		}
+
+		pages = append(pages, names(data))
+		prevs = append(prevs, prev)
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	expected := []string{"item-6,item-7,item-4", "item-5,item-2,item-3", "item-1"}
+	if strings.Join(pages, "|") != strings.Join(expected, "|") {
+		t.Fatalf("Expected pages %v, got %v", expected, pages)
+	}
+
+	if prevs[0] != "" {
+		t.Error("Expected no prev cursor on the first page")
+	}
+
+	data, next, prev, err := ListByCursorCtx[TestCursorItem](ctx, prevs[2], 3, nil, orderBy)
+	if err != nil {
+		t.Fatalf("ListByCursor prev failed: %v", err)
+	}
+	if names(data) != expected[1] || next == "" || prev == "" {
+		t.Errorf("Expected prev page %s with both cursors, got %s", expected[1], names(data))
+	}
+
+	t.Run("Tampered", func(t *testing.T) {
+		_, _, _, err := ListByCursorCtx[TestCursorItem](ctx, "e30."+strings.Split(prev, ".")[1], 3, nil, orderBy)
+		if !IsInvalidCursorError(err) {
+			t.Errorf("Expected invalid cursor error, got %v", err)
+		}
+	})
+
+	t.Run("Order Changed", func(t *testing.T) {
+		_, _, _, err := ListByCursorCtx[TestCursorItem](ctx, next, 3, nil, nil)
+		if !IsInvalidCursorError(err) {
+			t.Errorf("Expected invalid cursor error, got %v", err)
+		}
+	})
+}
@@ -1,7 +1,11 @@
 package gormx
 
 import (
+	"context"
 	"fmt"
+	"time"
+
+	zlogger "github.com/go-zoox/logger"
 
 	"gorm.io/driver/mysql"
 	"gorm.io/driver/postgres"
@@ -9,6 +13,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 	"gorm.io/gorm/schema"
+	"gorm.io/plugin/dbresolver"
 )
 
 var db *gorm.DB
@@ -23,6 +28,44 @@ type LoadDBOptions struct {
 	TablePrefix string
 	//
 	DryRun bool
+
+	// Replicas is the DSNs of the read replicas, which use the same engine as the primary.
+	// Reads are routed to the replicas, writes and transactions to the primary.
+	Replicas []string
+	// ReplicaPolicy is the routing policy of the replicas, default is round-robin.
+	ReplicaPolicy ReplicaPolicy
+	// ReplicaWeights is the weights of the replicas, used by the weighted policy,
+	// in the same order as Replicas.
+	ReplicaWeights []int
+
+	// MaxOpenConns is the maximum number of open connections, 0 means unlimited.
+	MaxOpenConns int
+	// MaxIdleConns is the maximum number of idle connections, 0 means the driver default.
+	MaxIdleConns int
+	// ConnMaxLifetime is the maximum time a connection may be reused, 0 means forever.
+	ConnMaxLifetime time.Duration
+	// ConnMaxIdleTime is the maximum time a connection may be idle, 0 means forever.
+	ConnMaxIdleTime time.Duration
+
+	// ConnectRetries is the number of retries when the database is not ready yet at startup.
+	ConnectRetries int
+	// ConnectRetryInterval is the initial wait between retries, doubled after each retry, default is 1s.
+	ConnectRetryInterval time.Duration
+	// ConnectRetryMaxInterval is the maximum wait between retries, default is 30s.
+	ConnectRetryMaxInterval time.Duration
+
+	// Logger is a custom gorm logger, which takes precedence over the other log options.
+	Logger logger.Interface
+	// LogLevel overrides the log level, default is Info, or Error if IsProd.
+	LogLevel logger.LogLevel
+	// UseZooxLogger writes the SQL logs through github.com/go-zoox/logger.
+	UseZooxLogger bool
+	// SlowThreshold is the threshold of slow queries, default is 200ms.
+	SlowThreshold time.Duration
+	// RedactParams hides the query parameters in the SQL logs.
+	RedactParams bool
+	// IgnoreRecordNotFoundError does not log ErrRecordNotFound.
+	IgnoreRecordNotFoundError bool
 }
 
 // GetDB returns the gorm.DB instance
@@ -34,10 +77,43 @@ func GetDB() *gorm.DB {
 	return db
 }
 
+// GetDBWithContext returns the gorm.DB instance bound to the given context,
+// so cancellation, deadlines and tracing spans propagate to every query.
+// If the context is pinned to a connection by WithConnection, that
+// connection is used instead of the default one, and if it is marked by
+// WithPrimary, the reads go to the primary instead of the replicas.
+// If it is bound to a transaction by WithTransaction, the transaction is used.
+func GetDBWithContext(ctx context.Context) *gorm.DB {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if tx := TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+
+	d := GetNamedDB(ConnectionFromContext(ctx)).WithContext(ctx)
+	if IsPrimaryContext(ctx) {
+		d = d.Clauses(dbresolver.Write)
+	}
+
+	return d
+}
+
 // SetDB sets the global gorm.DB instance.
 // This is useful for old projects that already use gorm.
 func SetDB(d *gorm.DB) {
 	db = d
+
+	if d != nil && d.Dialector != nil {
+		metadataEngine = d.Dialector.Name()
+	}
+
+	if d != nil && d.Config != nil {
+		if err := registerCallbacks(d); err != nil {
+			zlogger.Warnf("[gormx][set_db] registering callbacks failed: %s", err)
+		}
+	}
 }
 
 // GetEngine returns the database engine
@@ -62,45 +138,115 @@ func LoadDB(engine string, dsn string, opts ...func(*LoadDBOptions)) (err error)
 
 // Connect connects the database
 func Connect(engine string, dsn string, opts ...func(*LoadDBOptions)) (db *gorm.DB, err error) {
+	db, err = open(engine, dsn, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	metadataEngine = engine
+	metadataDSN = dsn
+
+	return db, nil
+}
+
+// open opens the database without touching the default connection metadata
+func open(engine string, dsn string, opts ...func(*LoadDBOptions)) (db *gorm.DB, err error) {
 	opt := &LoadDBOptions{}
 	for _, o := range opts {
 		o(opt)
 	}
 
-	var dialector gorm.Dialector
+	interval := opt.ConnectRetryInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	maxInterval := opt.ConnectRetryMaxInterval
+	if maxInterval <= 0 {
+		maxInterval = 30 * time.Second
+	}
+
+	for attempt := 0; ; attempt++ {
+		dialector, errx := openDialector(engine, dsn)
+		if errx != nil {
+			return nil, errx
+		}
+
+		db, err = gorm.Open(dialector, &gorm.Config{
+			SkipDefaultTransaction: false,
+			NamingStrategy: schema.NamingStrategy{
+				SingularTable: true,
+				TablePrefix:   opt.TablePrefix,
+			},
+			Logger:               newLogger(opt), // Print SQL queries
+			DisableAutomaticPing: false,
+			// DisableForeignKeyConstraintWhenMigrating: true,
+			DryRun: opt.DryRun,
+		})
+		if err == nil {
+			break
+		}
+
+		if attempt >= opt.ConnectRetries {
+			return nil, fmt.Errorf("connecting database failed: %s", err.Error())
+		}
+
+		zlogger.Warnf("[gormx][connect] connecting %s failed (retry %d/%d in %s): %s", engine, attempt+1, opt.ConnectRetries, interval, err)
+		time.Sleep(interval)
+
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+
+	if err := applyPoolOptions(db, opt); err != nil {
+		return nil, fmt.Errorf("configuring connection pool failed: %s", err.Error())
+	}
+
+	if err := registerCallbacks(db); err != nil {
+		return nil, fmt.Errorf("registering callbacks failed: %s", err.Error())
+	}
+
+	if len(opt.Replicas) > 0 {
+		if err := useReplicas(db, engine, opt); err != nil {
+			return nil, fmt.Errorf("connecting replicas failed: %s", err.Error())
+		}
+	}
+
+	return db, nil
+}
+
+func openDialector(engine string, dsn string) (gorm.Dialector, error) {
 	switch engine {
 	case "postgres":
-		dialector = postgres.Open(dsn)
+		return postgres.Open(dsn), nil
 	case "mysql":
-		dialector = mysql.Open(dsn)
+		return mysql.Open(dsn), nil
 	case "sqlite":
-		dialector = sqlite.Open(dsn)
+		return sqlite.Open(dsn), nil
 	default:
 		return nil, fmt.Errorf("unknown engine: %s", engine)
 	}
+}
 
-	metadataEngine = engine
-	metadataDSN = dsn
-
-	logLevel := logger.Info
-	if opt.IsProd {
-		logLevel = logger.Error
-	}
-
-	db, err = gorm.Open(dialector, &gorm.Config{
-		SkipDefaultTransaction: false,
-		NamingStrategy: schema.NamingStrategy{
-			SingularTable: true,
-			TablePrefix:   opt.TablePrefix,
-		},
-		Logger:               logger.Default.LogMode(logLevel), // Print SQL queries
-		DisableAutomaticPing: false,
-		// DisableForeignKeyConstraintWhenMigrating: true,
-		DryRun: opt.DryRun,
-	})
+func applyPoolOptions(db *gorm.DB, opt *LoadDBOptions) error {
+	sqlDB, err := db.DB()
 	if err != nil {
-		return nil, fmt.Errorf("connecting database failed: %s", err.Error())
+		return err
 	}
 
-	return db, nil
+	if opt.MaxOpenConns > 0 {
+		sqlDB.SetMaxOpenConns(opt.MaxOpenConns)
+	}
+	if opt.MaxIdleConns > 0 {
+		sqlDB.SetMaxIdleConns(opt.MaxIdleConns)
+	}
+	if opt.ConnMaxLifetime > 0 {
+		sqlDB.SetConnMaxLifetime(opt.ConnMaxLifetime)
+	}
+	if opt.ConnMaxIdleTime > 0 {
+		sqlDB.SetConnMaxIdleTime(opt.ConnMaxIdleTime)
+	}
+
+	return nil
 }
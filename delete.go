@@ -1,14 +1,23 @@
 package gormx
 
+import "context"
+
 // Delete deletes the record from database by the given conditions.
 // Supports both map[any]any and *Where as where condition.
 func Delete[T any, W WhereCondition](where W) (err error) {
+	return DeleteCtx[T](context.Background(), where)
+}
+
+// DeleteCtx deletes the record from database by the given conditions with context.
+func DeleteCtx[T any, W WhereCondition](ctx context.Context, where W) (err error) {
+	ctx = forWrite(ctx)
+
 	// Use FindOne with generic where condition
-	f, err := FindOne[T](where)
+	f, err := FindOneCtx[T](ctx, where)
 	if err != nil {
 		return err
 	}
 
-	err = GetDB().Delete(f).Error
+	err = GetDBWithContext(ctx).Delete(f).Error
 	return
 }
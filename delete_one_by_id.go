@@ -1,13 +1,22 @@
 package gormx
 
+import "context"
+
 // DeleteOneByID deletes one record by id.
 func DeleteOneByID[T any](id uint) (err error) {
+	return DeleteOneByIDCtx[T](context.Background(), id)
+}
+
+// DeleteOneByIDCtx deletes one record by id with context.
+func DeleteOneByIDCtx[T any](ctx context.Context, id uint) (err error) {
+	ctx = forWrite(ctx)
+
 	var f T
-	err = GetDB().First(&f, id).Error
+	err = GetDBWithContext(ctx).First(&f, id).Error
 	if err != nil {
 		return
 	}
 
-	err = GetDB().Delete(&f).Error
+	err = GetDBWithContext(ctx).Delete(&f).Error
 	return
 }
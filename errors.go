@@ -2,6 +2,9 @@ package gormx
 
 import (
 	"errors"
+	"fmt"
+	"net/http"
+	"strings"
 
 	"gorm.io/gorm"
 )
@@ -49,8 +52,128 @@ var (
 	ErrDuplicatedKey = gorm.ErrDuplicatedKey
 	// ErrForeignKeyViolated occurs when there is a foreign key constraint violation
 	ErrForeignKeyViolated = gorm.ErrForeignKeyViolated
+
+	// ErrUnknownField occurs when a query string filter or order by refers to a field which is not allowed
+	ErrUnknownField = errors.New("unknown field")
+	// ErrInvalidFieldValue occurs when a query string filter value cannot be converted to the field type
+	ErrInvalidFieldValue = errors.New("invalid field value")
+	// ErrInvalidFilter occurs when a query string filter cannot be parsed
+	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrInvalidCursor occurs when a pagination cursor is malformed, tampered or does not match the order by
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrStaleObject occurs when a versioned record has been changed since it was read
+	ErrStaleObject = errors.New("stale object")
+	// ErrVersionRequired occurs when a versioned model is updated by QueryBuilder without checking the version
+	ErrVersionRequired = errors.New("the version is required to update a versioned model")
+	// ErrNotSoftDeletable occurs when a soft delete API is used on a model without a gorm.DeletedAt field
+	ErrNotSoftDeletable = errors.New("model is not soft deletable")
 )
 
+// QueryBuildError is the errors of building the query of a QueryBuilder, e.g. an invalid where,
+// which are returned by the terminal methods instead of running the query.
+type QueryBuildError struct {
+	Errors []error
+}
+
+// Error returns the error message.
+func (e *QueryBuildError) Error() string {
+	messages := make([]string, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		messages = append(messages, err.Error())
+	}
+
+	return fmt.Sprintf("build query failed: %s", strings.Join(messages, "; "))
+}
+
+// Is reports whether any of the errors matches target, for errors.Is,
+// which does not unwrap multiple errors before Go 1.20.
+func (e *QueryBuildError) Is(target error) bool {
+	for _, err := range e.Errors {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// As finds the first of the errors that matches target, for errors.As.
+func (e *QueryBuildError) As(target interface{}) bool {
+	for _, err := range e.Errors {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UnknownFieldError is the error of a query string filter or order by field which is not allowed.
+type UnknownFieldError struct {
+	Field string
+	// Usage is filter or sort
+	Usage string
+}
+
+// Error returns the error message.
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("%s: %s is not allowed to %s", ErrUnknownField, e.Field, e.Usage)
+}
+
+// Unwrap returns ErrUnknownField.
+func (e *UnknownFieldError) Unwrap() error {
+	return ErrUnknownField
+}
+
+// Status returns the http status of the error, which is 400 Bad Request.
+func (e *UnknownFieldError) Status() int {
+	return http.StatusBadRequest
+}
+
+// FieldValueError is the error of a query string filter value which cannot be converted to the field type.
+type FieldValueError struct {
+	Field string
+	Value string
+	// Type is the expected type, e.g. int, bool, time, uuid or enum
+	Type string
+}
+
+// Error returns the error message.
+func (e *FieldValueError) Error() string {
+	return fmt.Sprintf("%s: %s of %s must be %s", ErrInvalidFieldValue, e.Value, e.Field, e.Type)
+}
+
+// Unwrap returns ErrInvalidFieldValue.
+func (e *FieldValueError) Unwrap() error {
+	return ErrInvalidFieldValue
+}
+
+// Status returns the http status of the error, which is 400 Bad Request.
+func (e *FieldValueError) Status() int {
+	return http.StatusBadRequest
+}
+
+// FilterError is the error of a query string filter which cannot be parsed.
+type FilterError struct {
+	Filter string
+	Reason string
+}
+
+// Error returns the error message.
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("%s: %s %s", ErrInvalidFilter, e.Filter, e.Reason)
+}
+
+// Unwrap returns ErrInvalidFilter.
+func (e *FilterError) Unwrap() error {
+	return ErrInvalidFilter
+}
+
+// Status returns the http status of the error, which is 400 Bad Request.
+func (e *FilterError) Status() int {
+	return http.StatusBadRequest
+}
+
 // IsRecordNotFoundError returns true if err is related to record not found error
 func IsRecordNotFoundError(err error) bool {
 	return errors.Is(err, ErrRecordNotFound)
@@ -155,3 +278,28 @@ func IsDuplicatedKeyError(err error) bool {
 func IsForeignKeyViolatedError(err error) bool {
 	return errors.Is(err, ErrForeignKeyViolated)
 }
+
+// IsUnknownFieldError returns true if err is related to unknown field error
+func IsUnknownFieldError(err error) bool {
+	return errors.Is(err, ErrUnknownField)
+}
+
+// IsInvalidFieldValueError returns true if err is related to invalid field value error
+func IsInvalidFieldValueError(err error) bool {
+	return errors.Is(err, ErrInvalidFieldValue)
+}
+
+// IsInvalidFilterError returns true if err is related to invalid filter error
+func IsInvalidFilterError(err error) bool {
+	return errors.Is(err, ErrInvalidFilter)
+}
+
+// IsInvalidCursorError returns true if err is related to invalid cursor error
+func IsInvalidCursorError(err error) bool {
+	return errors.Is(err, ErrInvalidCursor)
+}
+
+// IsStaleObjectError returns true if err is related to stale object error
+func IsStaleObjectError(err error) bool {
+	return errors.Is(err, ErrStaleObject)
+}
@@ -1,6 +1,7 @@
 package gormx
 
 import (
+	"context"
 	"errors"
 
 	"gorm.io/gorm"
@@ -9,7 +10,12 @@ import (
 // Exists returns true if the record exists.
 // Supports both map[any]any and *Where as where condition.
 func Exists[T any, W WhereCondition](where W) (bool, error) {
-	_, err := FindOne[T](where)
+	return ExistsCtx[T](context.Background(), where)
+}
+
+// ExistsCtx returns true if the record exists with context.
+func ExistsCtx[T any, W WhereCondition](ctx context.Context, where W) (bool, error) {
+	_, err := FindOneCtx[T](ctx, where)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return false, nil
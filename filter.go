@@ -0,0 +1,338 @@
+package gormx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FilterParam is the query string parameter of the JSON filter, e.g.
+//
+//	filter={"or":[{"status":"draft"},{"age":{"gte":18,"lt":65}}]}
+const FilterParam = "filter"
+
+// filterOperators maps the operators of the filter grammar to the where options.
+//
+//	eq       key[eq]=v          key = v
+//	ne       key[ne]=v          key <> v
+//	gt       key[gt]=v          key > v
+//	gte      key[gte]=v         key >= v
+//	lt       key[lt]=v          key < v
+//	lte      key[lte]=v         key <= v
+//	like     key[like]=v        key contains v, case-insensitive
+//	starts   key[starts]=v      key starts with v, case-insensitive
+//	ends     key[ends]=v        key ends with v, case-insensitive
+//	in       key[in]=a,b        key IN (a, b)
+//	nin      key[nin]=a,b       key NOT IN (a, b)
+//	between  key[between]=a,b   key BETWEEN a AND b
+//	null     key[null]=true     key IS NULL, or IS NOT NULL if false
+var filterOperators = map[string]func() *SetWhereOptions{
+	"eq":      func() *SetWhereOptions { return &SetWhereOptions{IsEqual: true} },
+	"ne":      func() *SetWhereOptions { return &SetWhereOptions{IsNotEqual: true} },
+	"gt":      func() *SetWhereOptions { return &SetWhereOptions{IsGreaterThan: true} },
+	"gte":     func() *SetWhereOptions { return &SetWhereOptions{IsGreaterOrEqual: true} },
+	"lt":      func() *SetWhereOptions { return &SetWhereOptions{IsLessThan: true} },
+	"lte":     func() *SetWhereOptions { return &SetWhereOptions{IsLessOrEqual: true} },
+	"like":    func() *SetWhereOptions { return &SetWhereOptions{IsFuzzy: true} },
+	"starts":  func() *SetWhereOptions { return &SetWhereOptions{IsStartsWith: true} },
+	"ends":    func() *SetWhereOptions { return &SetWhereOptions{IsEndsWith: true} },
+	"in":      func() *SetWhereOptions { return &SetWhereOptions{IsIn: true} },
+	"nin":     func() *SetWhereOptions { return &SetWhereOptions{IsNotIn: true} },
+	"between": func() *SetWhereOptions { return &SetWhereOptions{IsBetween: true} },
+	"null":    func() *SetWhereOptions { return &SetWhereOptions{IsNull: true} },
+}
+
+// legacyFilterSuffixes maps the value suffixes of the legacy grammar, e.g. name=foo:*, to the operators.
+var legacyFilterSuffixes = map[string]string{
+	"*":   "like",
+	"!":   "ne",
+	"in":  "in",
+	"!in": "nin",
+}
+
+var bracketFilterPattern = regexp.MustCompile(`^(.+)\[([a-z]+)\]$`)
+
+// addFilter adds the condition of the operator to the where.
+func addFilter(where *Where, key string, operator string, value interface{}, isOr bool) error {
+	newOptions, ok := filterOperators[operator]
+	if !ok {
+		return &FilterError{Filter: key, Reason: fmt.Sprintf("unknown operator %s", operator)}
+	}
+
+	opts := newOptions()
+	opts.IsOr = isOr
+
+	switch operator {
+	case "in", "nin", "between":
+		if vs, ok := value.(string); ok {
+			value = splitFilterList(vs)
+		}
+
+		if operator == "between" {
+			if vs, ok := value.([]string); !ok || len(vs) != 2 {
+				return &FilterError{Filter: key, Reason: "between requires 2 values"}
+			}
+		}
+	case "null":
+		isNull, err := strconv.ParseBool(fmt.Sprint(value))
+		if err != nil {
+			return &FilterError{Filter: key, Reason: "null requires true or false"}
+		}
+
+		if !isNull {
+			opts = &SetWhereOptions{IsNotNull: true, IsOr: isOr}
+		}
+		value = nil
+	default:
+		if _, ok := value.(string); !ok {
+			return &FilterError{Filter: key, Reason: fmt.Sprintf("%s requires a single value", operator)}
+		}
+	}
+
+	where.Add(key, value, opts)
+	return nil
+}
+
+// parseLegacyFilter parses the suffix grammar, e.g. name=foo:*, status=a,b:in,
+// a colon is taken literally when escaped as \:
+func parseLegacyFilter(value string) (string, string) {
+	if index := lastUnescapedColon(value); index != -1 {
+		if operator, ok := legacyFilterSuffixes[value[index+1:]]; ok {
+			return value[:index], operator
+		}
+	}
+
+	// values without a known suffix, e.g. 10:30, are literal
+	return value, "eq"
+}
+
+func lastUnescapedColon(value string) int {
+	for i := len(value) - 1; i >= 0; i-- {
+		if value[i] != ':' {
+			continue
+		}
+
+		backslashes := 0
+		for j := i - 1; j >= 0 && value[j] == '\\'; j-- {
+			backslashes++
+		}
+		if backslashes%2 == 0 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// unescapeFilterValue unescapes \: and \, and \\ of the legacy grammar.
+func unescapeFilterValue(value string) string {
+	if !strings.Contains(value, `\`) {
+		return value
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] == '\\' && i+1 < len(value) {
+			switch value[i+1] {
+			case ':', ',', '\\':
+				i++
+			}
+		}
+
+		b.WriteByte(value[i])
+	}
+
+	return b.String()
+}
+
+// splitFilterList splits a, b by the commas which are not escaped as \,
+func splitFilterList(value string) []string {
+	values := []string{}
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value):
+			b.WriteByte(value[i])
+			b.WriteByte(value[i+1])
+			i++
+		case value[i] == ',':
+			values = append(values, unescapeFilterValue(b.String()))
+			b.Reset()
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+
+	return append(values, unescapeFilterValue(b.String()))
+}
+
+// ParseFilter parses the JSON filter into a where, whose grammar is:
+//
+//	{"and": [filter, ...]}         all of the filters
+//	{"or": [filter, ...]}          any of the filters
+//	{"not": filter}                none of the filter
+//	{"key": value}                 key = value, or key IN (...) for arrays, or key IS NULL for null
+//	{"key": {"operator": value}}   the operators of the bracket grammar, e.g. {"age": {"gte": 18}}
+//
+// The keys of an object are joined by AND.
+func ParseFilter(filter string) (*Where, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(filter)))
+	decoder.UseNumber()
+
+	var node map[string]interface{}
+	if err := decoder.Decode(&node); err != nil {
+		return nil, &FilterError{Filter: FilterParam, Reason: fmt.Sprintf("invalid json: %s", err)}
+	}
+
+	where := NewWhere()
+	if err := parseFilterNode(where, node); err != nil {
+		return nil, err
+	}
+
+	return where, nil
+}
+
+func parseFilterNode(where *Where, node map[string]interface{}) error {
+	keys := make([]string, 0, len(node))
+	for key := range node {
+		keys = append(keys, key)
+	}
+	// map order is random, keep the SQL stable
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		value := node[key]
+
+		switch key {
+		case "and", "or":
+			children, ok := value.([]interface{})
+			if !ok {
+				return &FilterError{Filter: key, Reason: "requires an array of filters"}
+			}
+
+			group := NewWhere()
+			for _, child := range children {
+				childNode, ok := child.(map[string]interface{})
+				if !ok {
+					return &FilterError{Filter: key, Reason: "requires an array of filters"}
+				}
+
+				sub := NewWhere()
+				if err := parseFilterNode(sub, childNode); err != nil {
+					return err
+				}
+
+				if key == "or" {
+					group.Or(sub)
+				} else {
+					group.And(sub)
+				}
+			}
+
+			where.And(group)
+		case "not":
+			childNode, ok := value.(map[string]interface{})
+			if !ok {
+				return &FilterError{Filter: key, Reason: "requires a filter"}
+			}
+
+			sub := NewWhere()
+			if err := parseFilterNode(sub, childNode); err != nil {
+				return err
+			}
+
+			where.Not(sub)
+		default:
+			if err := parseFilterField(where, key, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func parseFilterField(where *Where, key string, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		return addFilter(where, key, "null", true, false)
+	case []interface{}:
+		values, err := filterStrings(key, v)
+		if err != nil {
+			return err
+		}
+
+		return addFilter(where, key, "in", values, false)
+	case map[string]interface{}:
+		operators := make([]string, 0, len(v))
+		for operator := range v {
+			operators = append(operators, operator)
+		}
+		sort.Strings(operators)
+
+		for _, operator := range operators {
+			var value interface{}
+			switch ov := v[operator].(type) {
+			case []interface{}:
+				values, err := filterStrings(key, ov)
+				if err != nil {
+					return err
+				}
+
+				value = values
+			default:
+				s, err := filterString(key, ov)
+				if err != nil {
+					return err
+				}
+
+				value = s
+			}
+
+			if err := addFilter(where, key, operator, value, false); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	default:
+		s, err := filterString(key, v)
+		if err != nil {
+			return err
+		}
+
+		return addFilter(where, key, "eq", s, false)
+	}
+}
+
+// filterString converts the JSON scalar to string, which is converted to the field type later.
+func filterString(key string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		return "", &FilterError{Filter: key, Reason: "requires a string, number or boolean"}
+	}
+}
+
+func filterStrings(key string, values []interface{}) ([]string, error) {
+	vs := make([]string, 0, len(values))
+	for _, value := range values {
+		s, err := filterString(key, value)
+		if err != nil {
+			return nil, err
+		}
+
+		vs = append(vs, s)
+	}
+
+	return vs, nil
+}
@@ -1,6 +1,13 @@
 package gormx
 
+import "context"
+
 // Find finds records.
 func Find[T any](page, pageSize uint, where *Where, orderBy *OrderBy) (data []*T, total int64, err error) {
 	return List[T](page, pageSize, where, orderBy)
 }
+
+// FindCtx finds records with context.
+func FindCtx[T any](ctx context.Context, page, pageSize uint, where *Where, orderBy *OrderBy) (data []*T, total int64, err error) {
+	return ListCtx[T](ctx, page, pageSize, where, orderBy)
+}
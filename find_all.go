@@ -1,6 +1,13 @@
 package gormx
 
+import "context"
+
 // FindAll finds all records.
 func FindAll[T any](where *Where, orderBy *OrderBy) (data []*T, err error) {
 	return ListALL[T](where, orderBy)
 }
+
+// FindAllCtx finds all records with context.
+func FindAllCtx[T any](ctx context.Context, where *Where, orderBy *OrderBy) (data []*T, err error) {
+	return ListALLCtx[T](ctx, where, orderBy)
+}
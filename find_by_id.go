@@ -1,9 +1,16 @@
 package gormx
 
+import "context"
+
 // FindByID finds a record by id.
 func FindByID[T any](id uint) (*T, error) {
+	return FindByIDCtx[T](context.Background(), id)
+}
+
+// FindByIDCtx finds a record by id with context.
+func FindByIDCtx[T any](ctx context.Context, id uint) (*T, error) {
 	var f T
-	if err := GetDB().First(&f, id).Error; err != nil {
+	if err := GetDBWithContext(ctx).First(&f, id).Error; err != nil {
 		return nil, err
 	}
 
@@ -1,10 +1,17 @@
 package gormx
 
-import "fmt"
+import (
+	"context"
+)
 
 // FindOne finds one record.
 // Supports both map[any]any and *Where as where condition.
 func FindOne[T any, W WhereCondition](where W) (*T, error) {
+	return FindOneCtx[T](context.Background(), where)
+}
+
+// FindOneCtx finds one record with context.
+func FindOneCtx[T any, W WhereCondition](ctx context.Context, where W) (*T, error) {
 	var f T
 
 	// Convert to *Where for unified processing
@@ -17,7 +24,7 @@ func FindOne[T any, W WhereCondition](where W) (*T, error) {
 		isSimple := true
 
 		for _, item := range w.Items {
-			if item.IsFuzzy || item.IsIn || item.IsNotIn || item.IsPlain || item.IsFullTextSearch || item.IsNotEqual {
+			if !item.isSimpleEqual() {
 				isSimple = false
 				break
 			}
@@ -26,7 +33,7 @@ func FindOne[T any, W WhereCondition](where W) (*T, error) {
 
 		if isSimple {
 			// Use simple map query
-			if err := GetDB().First(&f, simpleMap).Error; err != nil {
+			if err := GetDBWithContext(ctx).First(&f, simpleMap).Error; err != nil {
 				return nil, err
 			}
 			return &f, nil
@@ -34,16 +41,21 @@ func FindOne[T any, W WhereCondition](where W) (*T, error) {
 	}
 
 	// Use complex conditions
-	return FindOneWithComplexConditions[T](w, nil)
+	return FindOneWithComplexConditionsCtx[T](ctx, w, nil)
 }
 
 // FindOneWithComplexConditions finds one record.
 func FindOneWithComplexConditions[T any](where *Where, orderBy *OrderBy) (*T, error) {
+	return FindOneWithComplexConditionsCtx[T](context.Background(), where, orderBy)
+}
+
+// FindOneWithComplexConditionsCtx finds one record with context.
+func FindOneWithComplexConditionsCtx[T any](ctx context.Context, where *Where, orderBy *OrderBy) (*T, error) {
 	var f T
-	dataTx := GetDB()
+	dataTx := GetDBWithContext(ctx).Model(new(T))
 
 	if where != nil {
-		whereClause, whereValues, errx := where.Build()
+		whereClause, whereValues, errx := where.BuildFor(dataTx)
 		if errx != nil {
 			return nil, errx
 		}
@@ -54,15 +66,9 @@ func FindOneWithComplexConditions[T any](where *Where, orderBy *OrderBy) (*T, er
 	}
 
 	if orderBy != nil {
-		for _, order := range *orderBy {
-			// fmt.Println("order by:", order.Key, order.IsDESC)
-			orderMod := "ASC"
-			if order.IsDESC {
-				orderMod = "DESC"
-			}
-
-			orderStr := fmt.Sprintf("%s %s", order.Key, orderMod)
-			dataTx = dataTx.Order(orderStr)
+		var err error
+		if dataTx, err = orderBy.apply(dataTx, where); err != nil {
+			return nil, err
 		}
 	}
 
@@ -1,13 +1,22 @@
 package gormx
 
+import "context"
+
 // FindOneAndDelete finds one record and delete it.
 // Supports both map[any]any and *Where as where condition.
 func FindOneAndDelete[T any, W WhereCondition](where W) (*T, error) {
-	f, err := FindOne[T](where)
+	return FindOneAndDeleteCtx[T](context.Background(), where)
+}
+
+// FindOneAndDeleteCtx finds one record and delete it with context.
+func FindOneAndDeleteCtx[T any, W WhereCondition](ctx context.Context, where W) (*T, error) {
+	ctx = forWrite(ctx)
+
+	f, err := FindOneCtx[T](ctx, where)
 	if err != nil {
 		return nil, err
 	}
 
-	err = GetDB().Delete(f).Error
+	err = GetDBWithContext(ctx).Delete(f).Error
 	return f, err
 }
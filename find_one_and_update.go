@@ -1,14 +1,129 @@
 package gormx
 
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// FindOneAndUpdateOptions is the options of FindOneAndUpdate.
+type FindOneAndUpdateOptions struct {
+	// ReturnBefore returns the record before the update instead of after,
+	// like returnDocument: "before" of MongoDB.
+	ReturnBefore bool
+}
+
 // FindOneAndUpdate finds one and update it.
 // Supports both map[any]any and *Where as where condition.
-func FindOneAndUpdate[T any, W WhereCondition](where W, callback func(*T)) (*T, error) {
-	f, err := FindOne[T](where)
+// The record is locked by SELECT ... FOR UPDATE (where supported) and saved in a transaction.
+func FindOneAndUpdate[T any, W WhereCondition](where W, callback func(*T), opts ...func(*FindOneAndUpdateOptions)) (*T, error) {
+	return FindOneAndUpdateCtx[T](context.Background(), where, callback, opts...)
+}
+
+// FindOneAndUpdateCtx finds one and update it with context.
+func FindOneAndUpdateCtx[T any, W WhereCondition](ctx context.Context, where W, callback func(*T), opts ...func(*FindOneAndUpdateOptions)) (*T, error) {
+	return findOneAndUpdate(ctx, findOneForUpdate[T](where), saveCallback(callback), opts...)
+}
+
+// FindOneAndUpdateColumns finds one and only updates the given columns of it.
+// Supports both map[any]any and *Where as where condition.
+func FindOneAndUpdateColumns[T any, W WhereCondition](where W, updates map[string]any, opts ...func(*FindOneAndUpdateOptions)) (*T, error) {
+	return FindOneAndUpdateColumnsCtx[T](context.Background(), where, updates, opts...)
+}
+
+// FindOneAndUpdateColumnsCtx finds one and only updates the given columns of it with context.
+func FindOneAndUpdateColumnsCtx[T any, W WhereCondition](ctx context.Context, where W, updates map[string]any, opts ...func(*FindOneAndUpdateOptions)) (*T, error) {
+	return findOneAndUpdate(ctx, findOneForUpdate[T](where), updateColumns[T](updates), opts...)
+}
+
+// findOneForUpdate returns the finder of the record matching the where.
+func findOneForUpdate[T any, W WhereCondition](where W) func(tx *gorm.DB, f *T) error {
+	return func(tx *gorm.DB, f *T) error {
+		if w := ToWhere(where); w != nil {
+			whereClause, whereValues, err := w.BuildFor(tx.Model(new(T)))
+			if err != nil {
+				return err
+			}
+
+			if whereClause != "" {
+				tx = tx.Where(whereClause, whereValues...)
+			}
+		}
+
+		return tx.First(f).Error
+	}
+}
+
+// saveCallback returns the updater saving the record changed by the callback.
+func saveCallback[T any](callback func(*T)) func(tx *gorm.DB, f *T) error {
+	return func(tx *gorm.DB, f *T) error {
+		callback(f)
+
+		return saveOne(tx, f)
+	}
+}
+
+// updateColumns returns the updater of the given columns, which reloads the record.
+func updateColumns[T any](updates map[string]any) func(tx *gorm.DB, f *T) error {
+	return func(tx *gorm.DB, f *T) error {
+		if len(updates) != 0 {
+			tx := tx.Model(f)
+			versioned, isVersioned := any(f).(VersionedModel)
+			if isVersioned {
+				tx = tx.Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: VersionColumn}, Value: versioned.GetVersion()})
+			}
+
+			result := tx.Updates(versionedUpdates[T](updates))
+			if result.Error != nil {
+				return result.Error
+			}
+			// MySQL reports 0 rows if nothing changes, but the version always changes
+			if isVersioned && result.RowsAffected == 0 {
+				return ErrStaleObject
+			}
+		}
+
+		return tx.First(f).Error
+	}
+}
+
+// findOneAndUpdate finds the record locked for update, and updates it in a transaction.
+func findOneAndUpdate[T any](ctx context.Context, find func(tx *gorm.DB, f *T) error, update func(tx *gorm.DB, f *T) error, opts ...func(*FindOneAndUpdateOptions)) (*T, error) {
+	opt := &FindOneAndUpdateOptions{}
+	for _, o := range opts {
+		o(opt)
+	}
+
+	var before, after *T
+	err := Transaction(ctx, func(ctx context.Context) error {
+		f := new(T)
+		if err := find(lockForUpdate(GetDBWithContext(ctx)), f); err != nil {
+			return err
+		}
+
+		if opt.ReturnBefore {
+			// read it again, a copy would share the slices, maps and pointers changed by the update
+			before = new(T)
+			if err := find(GetDBWithContext(ctx), before); err != nil {
+				return err
+			}
+		}
+
+		if err := update(GetDBWithContext(ctx), f); err != nil {
+			return err
+		}
+
+		after = f
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	callback(f)
+	if opt.ReturnBefore {
+		return before, nil
+	}
 
-	return f, nil
+	return after, nil
 }
@@ -0,0 +1,61 @@
+package gormx
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type TestUpdateItem struct {
+	ID    uint     `gorm:"primarykey"`
+	Name  string   `gorm:"column:name"`
+	Count int      `gorm:"column:count"`
+	Tags  []string `gorm:"column:tags;serializer:json"`
+}
+
+func TestFindOneAndUpdate(t *testing.T) {
+	ctx := openTestDB(t, "test_update", &TestUpdateItem{})
+	GetDBWithContext(ctx).Create(&TestUpdateItem{Name: "a", Count: 1, Tags: []string{"x"}})
+
+	before, err := FindOneAndUpdateCtx[TestUpdateItem](ctx, map[any]any{"name": "a"}, func(one *TestUpdateItem) {
+		one.Count++
+		one.Tags[0] = "y"
+	}, func(opt *FindOneAndUpdateOptions) {
+		opt.ReturnBefore = true
+	})
+	if err != nil || before.Count != 1 || before.Tags[0] != "x" {
+		t.Fatalf("Expected the before image, got %+v %v", before, err)
+	}
+
+	after, err := FindOneByIDAndUpdateColumnsCtx[TestUpdateItem](ctx, 1, map[string]any{"name": "b"})
+	if err != nil || after.Name != "b" || after.Count != 2 {
+		t.Fatalf("Expected the persisted after image, got %+v %v", after, err)
+	}
+
+	if err := UpdateCtx(ctx, 1, func(one *TestUpdateItem) { one.Count = 10 }); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	one, _ := RetrieveCtx[TestUpdateItem](ctx, 1)
+	if one.Name != "b" || one.Count != 10 {
+		t.Errorf("Expected the updates to be persisted, got %+v", one)
+	}
+
+	t.Run("Transaction Rollback", func(t *testing.T) {
+		err := Transaction(ctx, func(ctx context.Context) error {
+			if _, err := FindOneByIDAndUpdateCtx[TestUpdateItem](ctx, 1, func(one *TestUpdateItem) { one.Count = 100 }); err != nil {
+				return err
+			}
+
+			return errors.New("rollback")
+		})
+		if err == nil {
+			t.Fatal("Expected the transaction to fail")
+		}
+
+		one, _ := RetrieveCtx[TestUpdateItem](ctx, 1)
+		if one.Count != 10 {
+			t.Errorf("Expected the update to be rolled back, got %d", one.Count)
+		}
+	})
+}
@@ -1,12 +1,21 @@
 package gormx
 
+import "context"
+
 // FindOneByIDAndDelete finds one record by id and delete it.
 func FindOneByIDAndDelete[T any](id uint) (*T, error) {
-	f, err := FindByID[T](id)
+	return FindOneByIDAndDeleteCtx[T](context.Background(), id)
+}
+
+// FindOneByIDAndDeleteCtx finds one record by id and delete it with context.
+func FindOneByIDAndDeleteCtx[T any](ctx context.Context, id uint) (*T, error) {
+	ctx = forWrite(ctx)
+
+	f, err := FindByIDCtx[T](ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	err = GetDB().Delete(f).Error
+	err = GetDBWithContext(ctx).Delete(f).Error
 	return f, err
 }
@@ -1,13 +1,35 @@
 package gormx
 
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
 // FindOneByIDAndUpdate finds one by id and update.
-func FindOneByIDAndUpdate[T any](id uint, callback func(*T)) (*T, error) {
-	f, err := FindByID[T](id)
-	if err != nil {
-		return nil, err
-	}
+// The record is locked by SELECT ... FOR UPDATE (where supported) and saved in a transaction.
+func FindOneByIDAndUpdate[T any](id uint, callback func(*T), opts ...func(*FindOneAndUpdateOptions)) (*T, error) {
+	return FindOneByIDAndUpdateCtx[T](context.Background(), id, callback, opts...)
+}
+
+// FindOneByIDAndUpdateCtx finds one by id and update with context.
+func FindOneByIDAndUpdateCtx[T any](ctx context.Context, id uint, callback func(*T), opts ...func(*FindOneAndUpdateOptions)) (*T, error) {
+	return findOneAndUpdate(ctx, findByIDForUpdate[T](id), saveCallback(callback), opts...)
+}
 
-	callback(f)
+// FindOneByIDAndUpdateColumns finds one by id and only updates the given columns of it.
+func FindOneByIDAndUpdateColumns[T any](id uint, updates map[string]any, opts ...func(*FindOneAndUpdateOptions)) (*T, error) {
+	return FindOneByIDAndUpdateColumnsCtx[T](context.Background(), id, updates, opts...)
+}
 
-	return f, nil
+// FindOneByIDAndUpdateColumnsCtx finds one by id and only updates the given columns of it with context.
+func FindOneByIDAndUpdateColumnsCtx[T any](ctx context.Context, id uint, updates map[string]any, opts ...func(*FindOneAndUpdateOptions)) (*T, error) {
+	return findOneAndUpdate(ctx, findByIDForUpdate[T](id), updateColumns[T](updates), opts...)
+}
+
+// findByIDForUpdate returns the finder of the record of the id.
+func findByIDForUpdate[T any](id uint) func(tx *gorm.DB, f *T) error {
+	return func(tx *gorm.DB, f *T) error {
+		return tx.First(f, id).Error
+	}
 }
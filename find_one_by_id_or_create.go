@@ -1,16 +1,15 @@
 package gormx
 
+import "context"
+
 // FindOneByIDOrCreate finds one record by id or create a new one.
 func FindOneByIDOrCreate[T any](id uint, callback func(*T)) (*T, error) {
-	f, err := FindByID[T](id)
-	if err != nil {
-		var tmp T
-		callback(&tmp)
-
-		if f, err = Create(&tmp); err != nil {
-			return nil, err
-		}
-	}
+	return FindOneByIDOrCreateCtx[T](context.Background(), id, callback)
+}
 
-	return f, nil
+// FindOneByIDOrCreateCtx finds one record by id or create a new one with context.
+func FindOneByIDOrCreateCtx[T any](ctx context.Context, id uint, callback func(*T)) (*T, error) {
+	return findOneOrCreate(ctx, func(ctx context.Context) (*T, error) {
+		return FindByIDCtx[T](ctx, id)
+	}, callback)
 }
@@ -1,27 +1,17 @@
 package gormx
 
-import (
-	"errors"
-
-	"gorm.io/gorm"
-)
+import "context"
 
 // FindOneOrCreate find one or create one.
 // Supports both map[any]any and *Where as where condition.
+// It is atomic if the where is covered by a unique index, see Upsert.
 func FindOneOrCreate[T any, W WhereCondition](where W, callback func(*T)) (*T, error) {
-	f, err := FindOne[T](where)
-	if err != nil {
-		if !errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, err
-		}
-
-		var tmp T
-		callback(&tmp)
-
-		if f, err = Create(&tmp); err != nil {
-			return nil, err
-		}
-	}
+	return FindOneOrCreateCtx[T](context.Background(), where, callback)
+}
 
-	return f, nil
+// FindOneOrCreateCtx find one or create one with context.
+func FindOneOrCreateCtx[T any, W WhereCondition](ctx context.Context, where W, callback func(*T)) (*T, error) {
+	return findOneOrCreate(ctx, func(ctx context.Context) (*T, error) {
+		return FindOneCtx[T](ctx, where)
+	}, callback)
 }
@@ -0,0 +1,241 @@
+package gormx
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// FullTextSearch is the strategy of the full text search of the q keyword.
+type FullTextSearch interface {
+	// Build builds the condition matching the keyword on the fields.
+	Build(dialect string, fields []string, keyword string) (query string, args []interface{}, err error)
+	// Rank builds the relevance score expression of the keyword on the fields, higher is better.
+	Rank(dialect string, fields []string, keyword string) (expr string, args []interface{}, err error)
+}
+
+// FullTextSearchModel is implemented by the models that select their full text search strategy.
+type FullTextSearchModel interface {
+	FullTextSearch() FullTextSearch
+}
+
+// FullTextSearchPrefixSuffix marks the keyword as a prefix search, e.g. q=go:*
+const FullTextSearchPrefixSuffix = ":*"
+
+var regconfigPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+var tsqueryTermPattern = regexp.MustCompile(`[^\pL\pN_]+`)
+
+// parseFullTextKeyword returns the keyword without the prefix suffix, and if it is a prefix search.
+func parseFullTextKeyword(keyword string) (string, bool) {
+	if strings.HasSuffix(keyword, FullTextSearchPrefixSuffix) {
+		return strings.TrimSuffix(keyword, FullTextSearchPrefixSuffix), true
+	}
+
+	return keyword, false
+}
+
+// LikeFullTextSearch matches the keyword by case-insensitive LIKE on every field,
+// which is the default strategy and works on every dialect, but cannot use indexes.
+type LikeFullTextSearch struct{}
+
+// Build implements FullTextSearch.
+func (s *LikeFullTextSearch) Build(dialect string, fields []string, keyword string) (string, []interface{}, error) {
+	keyword, _ = parseFullTextKeyword(keyword)
+
+	w := &Where{Dialect: dialect}
+	keywordFuzzy := fmt.Sprintf("%%%s%%", escapeLike(keyword))
+	qs := []string{}
+	args := []interface{}{}
+	for _, field := range fields {
+		qs = append(qs, w.buildLike(field))
+		args = append(args, keywordFuzzy)
+	}
+
+	return fmt.Sprintf("(%s)", strings.Join(qs, " OR ")), args, nil
+}
+
+// Rank implements FullTextSearch.
+func (s *LikeFullTextSearch) Rank(dialect string, fields []string, keyword string) (string, []interface{}, error) {
+	return "", nil, fmt.Errorf("rank is not supported by like full text search")
+}
+
+// PostgresFullTextSearch matches the keyword by to_tsvector @@ plainto_tsquery,
+// or to_tsquery with prefix matching if the keyword ends with :*
+type PostgresFullTextSearch struct {
+	// Config is the text search configuration, default is simple.
+	Config string
+	// Weights is the weight (A, B, C or D) of the fields, unweighted fields are D.
+	Weights map[string]string
+}
+
+func (s *PostgresFullTextSearch) config() (string, error) {
+	if s.Config == "" {
+		return "simple", nil
+	}
+
+	if !regconfigPattern.MatchString(s.Config) {
+		return "", fmt.Errorf("invalid text search config: %s", s.Config)
+	}
+
+	return s.Config, nil
+}
+
+func (s *PostgresFullTextSearch) build(fields []string, keyword string) (vector string, query string, arg string, err error) {
+	config, err := s.config()
+	if err != nil {
+		return "", "", "", err
+	}
+
+	vectors := []string{}
+	for _, field := range fields {
+		vector := fmt.Sprintf("to_tsvector('%s', coalesce(%s, ''))", config, field)
+		if weight, ok := s.Weights[field]; ok {
+			switch weight {
+			case "A", "B", "C", "D":
+				vector = fmt.Sprintf("setweight(%s, '%s')", vector, weight)
+			default:
+				return "", "", "", fmt.Errorf("invalid weight(%s) of field %s", weight, field)
+			}
+		}
+
+		vectors = append(vectors, vector)
+	}
+
+	keyword, isPrefix := parseFullTextKeyword(keyword)
+	if !isPrefix {
+		return strings.Join(vectors, " || "), fmt.Sprintf("plainto_tsquery('%s', ?)", config), keyword, nil
+	}
+
+	terms := []string{}
+	for _, term := range tsqueryTermPattern.Split(keyword, -1) {
+		if term != "" {
+			terms = append(terms, term+":*")
+		}
+	}
+
+	return strings.Join(vectors, " || "), fmt.Sprintf("to_tsquery('%s', ?)", config), strings.Join(terms, " & "), nil
+}
+
+// Build implements FullTextSearch.
+func (s *PostgresFullTextSearch) Build(dialect string, fields []string, keyword string) (string, []interface{}, error) {
+	vector, query, arg, err := s.build(fields, keyword)
+	if err != nil || arg == "" {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("(%s) @@ %s", vector, query), []interface{}{arg}, nil
+}
+
+// Rank implements FullTextSearch.
+func (s *PostgresFullTextSearch) Rank(dialect string, fields []string, keyword string) (string, []interface{}, error) {
+	vector, query, arg, err := s.build(fields, keyword)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("ts_rank(%s, %s)", vector, query), []interface{}{arg}, nil
+}
+
+// MySQLFullTextSearch matches the keyword by MATCH (...) AGAINST (...),
+// the fields must be covered by a FULLTEXT index.
+type MySQLFullTextSearch struct {
+	// BooleanMode uses IN BOOLEAN MODE instead of IN NATURAL LANGUAGE MODE,
+	// which also supports prefix matching if the keyword ends with :*
+	BooleanMode bool
+}
+
+func (s *MySQLFullTextSearch) build(fields []string, keyword string) (string, string) {
+	keyword, isPrefix := parseFullTextKeyword(keyword)
+
+	mode := "IN NATURAL LANGUAGE MODE"
+	if s.BooleanMode {
+		mode = "IN BOOLEAN MODE"
+		if isPrefix {
+			terms := strings.Fields(keyword)
+			for i, term := range terms {
+				terms[i] = term + "*"
+			}
+			keyword = strings.Join(terms, " ")
+		}
+	}
+
+	return fmt.Sprintf("MATCH (%s) AGAINST (? %s)", strings.Join(fields, ", "), mode), keyword
+}
+
+// Build implements FullTextSearch.
+func (s *MySQLFullTextSearch) Build(dialect string, fields []string, keyword string) (string, []interface{}, error) {
+	query, arg := s.build(fields, keyword)
+	return query, []interface{}{arg}, nil
+}
+
+// Rank implements FullTextSearch.
+func (s *MySQLFullTextSearch) Rank(dialect string, fields []string, keyword string) (string, []interface{}, error) {
+	query, arg := s.build(fields, keyword)
+	return query, []interface{}{arg}, nil
+}
+
+// SQLiteFullTextSearch matches the keyword by an FTS5 virtual table,
+// whose rowid is the primary key of the model table.
+type SQLiteFullTextSearch struct {
+	// Table is the FTS5 virtual table.
+	Table string
+	// Key is the column of the model table matching the rowid of the FTS5 table, default is id.
+	Key string
+}
+
+func (s *SQLiteFullTextSearch) build(fields []string, keyword string) (string, error) {
+	if s.Table == "" {
+		return "", fmt.Errorf("fts5 table is required")
+	}
+
+	keyword, isPrefix := parseFullTextKeyword(keyword)
+
+	terms := []string{}
+	for _, term := range strings.Fields(keyword) {
+		term = fmt.Sprintf(`"%s"`, strings.ReplaceAll(term, `"`, `""`))
+		if isPrefix {
+			term += "*"
+		}
+
+		terms = append(terms, term)
+	}
+	if len(terms) == 0 {
+		return "", nil
+	}
+
+	match := strings.Join(terms, " ")
+	if len(fields) > 0 {
+		match = fmt.Sprintf("{%s} : (%s)", strings.Join(fields, " "), match)
+	}
+
+	return match, nil
+}
+
+func (s *SQLiteFullTextSearch) key() string {
+	if s.Key == "" {
+		return "id"
+	}
+
+	return s.Key
+}
+
+// Build implements FullTextSearch.
+func (s *SQLiteFullTextSearch) Build(dialect string, fields []string, keyword string) (string, []interface{}, error) {
+	match, err := s.build(fields, keyword)
+	if err != nil || match == "" {
+		return "", nil, err
+	}
+
+	return fmt.Sprintf("%s IN (SELECT rowid FROM %s WHERE %s MATCH ?)", s.key(), s.Table, s.Table), []interface{}{match}, nil
+}
+
+// Rank implements FullTextSearch.
+func (s *SQLiteFullTextSearch) Rank(dialect string, fields []string, keyword string) (string, []interface{}, error) {
+	match, err := s.build(fields, keyword)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// bm25 is lower for better matches
+	return fmt.Sprintf("(SELECT -bm25(%s) FROM %s WHERE %s MATCH ? AND %s.rowid = %s)", s.Table, s.Table, s.Table, s.Table, s.key()), []interface{}{match}, nil
+}
@@ -0,0 +1,98 @@
+package gormx
+
+import (
+	"testing"
+)
+
+type TestSearchArticle struct {
+	ID    uint   `gorm:"primarykey"`
+	Title string `gorm:"column:title"`
+	Body  string `gorm:"column:body"`
+}
+
+func (TestSearchArticle) FullTextSearch() FullTextSearch {
+	return &SQLiteFullTextSearch{Table: "test_search_article_fts"}
+}
+
+func TestFullTextSearch_Build(t *testing.T) {
+	fields := []string{"title", "body"}
+
+	t.Run("Postgres", func(t *testing.T) {
+		s := &PostgresFullTextSearch{Config: "english", Weights: map[string]string{"title": "A"}}
+
+		query, args, err := s.Build("postgres", fields, "hello world")
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		assertWhere(t, query, args,
+			"(setweight(to_tsvector('english', coalesce(title, '')), 'A') || to_tsvector('english', coalesce(body, ''))) @@ plainto_tsquery('english', ?)",
+			[]interface{}{"hello world"})
+
+		rank, args, err := s.Rank("postgres", fields, "hel wor:*")
+		if err != nil {
+			t.Fatalf("Rank failed: %v", err)
+		}
+		assertWhere(t, rank, args,
+			"ts_rank(setweight(to_tsvector('english', coalesce(title, '')), 'A') || to_tsvector('english', coalesce(body, '')), to_tsquery('english', ?))",
+			[]interface{}{"hel:* & wor:*"})
+	})
+
+	t.Run("Postgres Invalid Config", func(t *testing.T) {
+		s := &PostgresFullTextSearch{Config: "english'; --"}
+		if _, _, err := s.Build("postgres", fields, "hello"); err == nil {
+			t.Error("Expected error for invalid config")
+		}
+	})
+
+	t.Run("MySQL", func(t *testing.T) {
+		s := &MySQLFullTextSearch{BooleanMode: true}
+
+		query, args, err := s.Build("mysql", fields, "hel wor:*")
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		assertWhere(t, query, args, "MATCH (title, body) AGAINST (? IN BOOLEAN MODE)", []interface{}{"hel* wor*"})
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		s := &SQLiteFullTextSearch{Table: "article_fts"}
+
+		query, args, err := s.Build("sqlite", fields, `say "hi"`)
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+		assertWhere(t, query, args,
+			"id IN (SELECT rowid FROM article_fts WHERE article_fts MATCH ?)",
+			[]interface{}{`{title body} : ("say" """hi""")`})
+	})
+}
+
+func TestWhere_FullTextSearchModel(t *testing.T) {
+	d, err := open("sqlite", ":memory:", func(opt *LoadDBOptions) {
+		opt.IsProd = true
+		opt.DryRun = true
+	})
+	if err != nil {
+		t.Fatalf("Opening failed: %v", err)
+	}
+
+	where := NewWhere()
+	where.FullTextSearchFields = []string{"title"}
+	where.Set("q", "hello")
+
+	query, args, err := where.BuildFor(d.Model(&TestSearchArticle{}))
+	if err != nil {
+		t.Fatalf("BuildFor failed: %v", err)
+	}
+	assertWhere(t, query, args,
+		"id IN (SELECT rowid FROM test_search_article_fts WHERE test_search_article_fts MATCH ?)",
+		[]interface{}{`{title} : ("hello")`})
+
+	rank, _, err := where.BuildRankFor(d.Model(&TestSearchArticle{}))
+	if err != nil {
+		t.Fatalf("BuildRankFor failed: %v", err)
+	}
+	if rank == "" {
+		t.Error("Expected a rank expression")
+	}
+}
@@ -1,6 +1,7 @@
 package gormx
 
 import (
+	"context"
 	"time"
 
 	"github.com/go-zoox/ioc"
@@ -30,74 +31,181 @@ func (m *ModelImpl) Model() ioc.Container {
 
 // ModelGeneric ...
 type ModelGeneric[T any] struct {
+	ctx        context.Context
+	connection string
+}
+
+// WithContext returns a copy of the generic model bound to the given context.
+func (m *ModelGeneric[T]) WithContext(ctx context.Context) *ModelGeneric[T] {
+	return &ModelGeneric[T]{
+		ctx:        ctx,
+		connection: m.getConnection(),
+	}
+}
+
+// UseConnection returns a copy of the generic model pinned to the named connection.
+func (m *ModelGeneric[T]) UseConnection(name string) *ModelGeneric[T] {
+	return &ModelGeneric[T]{
+		ctx:        m.getContextRaw(),
+		connection: name,
+	}
+}
+
+func (m *ModelGeneric[T]) getConnection() string {
+	if m == nil {
+		return ""
+	}
+
+	return m.connection
+}
+
+func (m *ModelGeneric[T]) getContextRaw() context.Context {
+	if m == nil {
+		return nil
+	}
+
+	return m.ctx
+}
+
+func (m *ModelGeneric[T]) getContext() context.Context {
+	ctx := m.getContextRaw()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if connection := m.getConnection(); connection != "" {
+		ctx = WithConnection(ctx, connection)
+	}
+
+	return ctx
 }
 
 // List ...
 func (m *ModelGeneric[T]) List(page, pageSize uint, where *Where, orderBy *OrderBy) (data []*T, total int64, err error) {
-	return List[T](page, pageSize, where, orderBy)
+	return ListCtx[T](m.getContext(), page, pageSize, where, orderBy)
 }
 
 // Create ...
 func (m *ModelGeneric[T]) Create(one *T) (*T, error) {
-	return Create(one)
+	return CreateCtx(m.getContext(), one)
 }
 
 // Retrieve ...
 func (m *ModelGeneric[T]) Retrieve(id uint) (*T, error) {
-	return Retrieve[T](id)
+	return RetrieveCtx[T](m.getContext(), id)
 }
 
 // Update ...
 func (m *ModelGeneric[T]) Update(id uint, uc func(*T)) (err error) {
-	return Update(id, uc)
+	return UpdateCtx(m.getContext(), id, uc)
 }
 
 // Delete ...
 func (m *ModelGeneric[T]) Delete(id uint) (err error) {
-	return DeleteOneByID[T](id)
+	return DeleteOneByIDCtx[T](m.getContext(), id)
 }
 
 // Save ...
 func (m *ModelGeneric[T]) Save() error {
-	return Save(m)
+	return SaveCtx(m.getContext(), m)
 }
 
 // GetMany ...
 func (m *ModelGeneric[T]) GetMany(ids []uint) (data []*T, err error) {
-	return GetMany[T](ids)
+	return GetManyCtx[T](m.getContext(), ids)
 }
 
 // Exists ...
 func (m *ModelGeneric[T]) Exists(where map[any]any) (bool, error) {
-	return Exists[*T](where)
+	return ExistsCtx[*T](m.getContext(), where)
 }
 
 // FindByID ...
 func (m *ModelGeneric[T]) FindByID(id uint) (*T, error) {
-	return FindByID[T](id)
+	return FindByIDCtx[T](m.getContext(), id)
 }
 
 // FindOne ...
 func (m *ModelGeneric[T]) FindOne(where map[any]any) (*T, error) {
-	return FindOne[T](where)
+	return FindOneCtx[T](m.getContext(), where)
 }
 
 // FindAll ...
 func (m *ModelGeneric[T]) FindAll(where *Where, orderBy *OrderBy) ([]*T, error) {
-	return FindAll[T](where, orderBy)
+	return FindAllCtx[T](m.getContext(), where, orderBy)
 }
 
 // FindOneOrCreate ...
 func (m *ModelGeneric[T]) FindOneOrCreate(where map[any]any, callback func(*T)) (*T, error) {
-	return FindOneOrCreate[T](where, callback)
+	return FindOneOrCreateCtx[T](m.getContext(), where, callback)
 }
 
 // FindOneAndUpdate ...
-func (m *ModelGeneric[T]) FindOneAndUpdate(where map[any]any, callback func(*T)) (*T, error) {
-	return FindOneAndUpdate(where, callback)
+func (m *ModelGeneric[T]) FindOneAndUpdate(where map[any]any, callback func(*T), opts ...func(*FindOneAndUpdateOptions)) (*T, error) {
+	return FindOneAndUpdateCtx[T](m.getContext(), where, callback, opts...)
+}
+
+// FindOneAndUpdateColumns ...
+func (m *ModelGeneric[T]) FindOneAndUpdateColumns(where map[any]any, updates map[string]any, opts ...func(*FindOneAndUpdateOptions)) (*T, error) {
+	return FindOneAndUpdateColumnsCtx[T](m.getContext(), where, updates, opts...)
+}
+
+// FindOneByIDAndUpdate ...
+func (m *ModelGeneric[T]) FindOneByIDAndUpdate(id uint, callback func(*T), opts ...func(*FindOneAndUpdateOptions)) (*T, error) {
+	return FindOneByIDAndUpdateCtx[T](m.getContext(), id, callback, opts...)
+}
+
+// FindOneByIDAndUpdateColumns ...
+func (m *ModelGeneric[T]) FindOneByIDAndUpdateColumns(id uint, updates map[string]any, opts ...func(*FindOneAndUpdateOptions)) (*T, error) {
+	return FindOneByIDAndUpdateColumnsCtx[T](m.getContext(), id, updates, opts...)
 }
 
 // FindOneAndDelete ...
 func (m *ModelGeneric[T]) FindOneAndDelete(where map[any]any) (*T, error) {
-	return FindOneAndDelete[T](where)
+	return FindOneAndDeleteCtx[T](m.getContext(), where)
+}
+
+// CreateMany ...
+func (m *ModelGeneric[T]) CreateMany(data []*T, opts ...func(*BatchOptions)) (int64, error) {
+	return CreateManyCtx(m.getContext(), data, opts...)
+}
+
+// UpdateMany ...
+func (m *ModelGeneric[T]) UpdateMany(where *Where, updates map[string]any) (int64, error) {
+	return UpdateManyCtx[T](m.getContext(), where, updates)
+}
+
+// DeleteMany ...
+func (m *ModelGeneric[T]) DeleteMany(where *Where) (int64, error) {
+	return DeleteManyCtx[T](m.getContext(), where)
+}
+
+// DeleteManyByIDs ...
+func (m *ModelGeneric[T]) DeleteManyByIDs(ids []uint, opts ...func(*BatchOptions)) (int64, error) {
+	return DeleteManyByIDsCtx[T](m.getContext(), ids, opts...)
+}
+
+// AuditHistory ...
+func (m *ModelGeneric[T]) AuditHistory(id uint) ([]*AuditLog, error) {
+	return GetAuditHistoryCtx[T](m.getContext(), id)
+}
+
+// Restore ...
+func (m *ModelGeneric[T]) Restore(id uint) error {
+	return RestoreCtx[T](m.getContext(), id)
+}
+
+// ListTrashed ...
+func (m *ModelGeneric[T]) ListTrashed(page, pageSize uint, where *Where, orderBy *OrderBy) (data []*T, total int64, err error) {
+	return ListTrashedCtx[T](m.getContext(), page, pageSize, where, orderBy)
+}
+
+// ForceDelete ...
+func (m *ModelGeneric[T]) ForceDelete(id uint) error {
+	return ForceDeleteCtx[T](m.getContext(), id)
+}
+
+// PurgeDeletedBefore ...
+func (m *ModelGeneric[T]) PurgeDeletedBefore(before time.Time) (int64, error) {
+	return PurgeDeletedBeforeCtx[T](m.getContext(), before)
 }
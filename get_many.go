@@ -1,8 +1,15 @@
 package gormx
 
+import "context"
+
 // GetMany gets many records by ids.
 func GetMany[T any](ids []uint) (data []*T, err error) {
-	err = GetDB().
+	return GetManyCtx[T](context.Background(), ids)
+}
+
+// GetManyCtx gets many records by ids with context.
+func GetManyCtx[T any](ctx context.Context, ids []uint) (data []*T, err error) {
+	err = GetDBWithContext(ctx).
 		Where("id IN (?)", ids).
 		Find(&data).Error
 	return
@@ -1,7 +1,14 @@
 package gormx
 
+import "context"
+
 // GetOrCreate gets or creates a record.
 // Supports both map[any]any and *Where as where condition.
 func GetOrCreate[T any, W WhereCondition](where W, callback func(*T)) (*T, error) {
 	return FindOneOrCreate[T](where, callback)
 }
+
+// GetOrCreateCtx gets or creates a record with context.
+func GetOrCreateCtx[T any, W WhereCondition](ctx context.Context, where W, callback func(*T)) (*T, error) {
+	return FindOneOrCreateCtx[T](ctx, where, callback)
+}
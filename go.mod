@@ -8,10 +8,11 @@ require (
 	github.com/go-zoox/zoox v1.10.15
 	go.mongodb.org/mongo-driver v1.14.0
 	gorm.io/datatypes v1.2.4
-	gorm.io/driver/mysql v1.5.6
+	gorm.io/driver/mysql v1.5.7
 	gorm.io/driver/postgres v1.5.2
 	gorm.io/driver/sqlite v1.5.2
-	gorm.io/gorm v1.25.11
+	gorm.io/gorm v1.25.12
+	gorm.io/plugin/dbresolver v1.5.3
 )
 
 require (
@@ -1,9 +1,16 @@
 package gormx
 
+import "context"
+
 // Has returns true if the record exists.
 func Has[T any](where map[string]any) bool {
+	return HasCtx[T](context.Background(), where)
+}
+
+// HasCtx returns true if the record exists with context.
+func HasCtx[T any](ctx context.Context, where map[string]any) bool {
 	var f T
-	if err := GetDB().First(&f, where).Error; err != nil {
+	if err := GetDBWithContext(ctx).First(&f, where).Error; err != nil {
 		return false
 	}
 
@@ -0,0 +1,50 @@
+package gormx
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// HealthStatus is the health of a database connection.
+type HealthStatus struct {
+	Connection string        `json:"connection"`
+	Engine     string        `json:"engine"`
+	Latency    time.Duration `json:"latency"`
+	Stats      sql.DBStats   `json:"stats"`
+}
+
+// Ping pings the database, using the connection pinned in ctx if any.
+func Ping(ctx context.Context) error {
+	_, err := Health(ctx)
+	return err
+}
+
+// Health pings the database and reports the connection pool stats,
+// useful for readiness probes.
+func Health(ctx context.Context) (*HealthStatus, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	connection := ConnectionFromContext(ctx)
+	sqlDB, err := GetDBWithContext(ctx).DB()
+	if err != nil {
+		return nil, err
+	}
+
+	status := &HealthStatus{
+		Connection: connection,
+		Engine:     GetNamedEngine(connection),
+	}
+
+	start := time.Now()
+	err = sqlDB.PingContext(ctx)
+	status.Latency = time.Since(start)
+	status.Stats = sqlDB.Stats()
+	if err != nil {
+		return status, err
+	}
+
+	return status, nil
+}
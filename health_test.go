@@ -0,0 +1,48 @@
+package gormx
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestHealth(t *testing.T) {
+	err := LoadNamedDB("test_health", "sqlite", ":memory:", func(opt *LoadDBOptions) {
+		opt.IsProd = true
+		opt.MaxOpenConns = 3
+		opt.ConnMaxLifetime = time.Minute
+	})
+	if err != nil {
+		t.Fatalf("LoadNamedDB failed: %v", err)
+	}
+
+	status, err := Health(WithConnection(context.Background(), "test_health"))
+	if err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+
+	if status.Engine != "sqlite" {
+		t.Errorf("Expected engine sqlite, got %s", status.Engine)
+	}
+
+	if status.Stats.MaxOpenConnections != 3 {
+		t.Errorf("Expected max open connections 3, got %d", status.Stats.MaxOpenConnections)
+	}
+}
+
+func TestConnectRetry(t *testing.T) {
+	start := time.Now()
+	_, err := Connect("sqlite", "/nonexistent/dir/test.db", func(opt *LoadDBOptions) {
+		opt.IsProd = true
+		opt.ConnectRetries = 2
+		opt.ConnectRetryInterval = 10 * time.Millisecond
+	})
+	if err == nil {
+		t.Fatal("Expected connecting to an unreachable database to fail")
+	}
+
+	// 10ms + 20ms of backoff
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("Expected retries with backoff, finished in %s", elapsed)
+	}
+}
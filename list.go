@@ -1,7 +1,18 @@
 package gormx
 
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
 // List lists records.
 func List[T any](page, pageSize uint, where *Where, orderBy *OrderBy) (data []*T, total int64, err error) {
+	return ListCtx[T](context.Background(), page, pageSize, where, orderBy)
+}
+
+// ListCtx lists records with context.
+func ListCtx[T any](ctx context.Context, page, pageSize uint, where *Where, orderBy *OrderBy) (data []*T, total int64, err error) {
 	offset := int((page - 1) * pageSize)
 	limit := int(pageSize)
 
@@ -24,20 +35,9 @@ func List[T any](page, pageSize uint, where *Where, orderBy *OrderBy) (data []*T
 	// }
 	// whereClause := strings.Join(whereClauses, " AND ")
 
-	whereClause, whereValues, errx := where.Build()
-	if errx != nil {
-		return nil, 0, errx
-	}
-
-	dataTx := GetDB().Model(new(T))
-
-	if orderBy != nil {
-		for _, order := range *orderBy {
-			dataTx = dataTx.Order(order.Clause())
-		}
-	}
-	if whereClause != "" {
-		dataTx = dataTx.Where(whereClause, whereValues...)
+	dataTx, err := listQuery[T](ctx, where, orderBy)
+	if err != nil {
+		return nil, 0, err
 	}
 
 	err = dataTx.
@@ -49,3 +49,24 @@ func List[T any](page, pageSize uint, where *Where, orderBy *OrderBy) (data []*T
 
 	return
 }
+
+// listQuery returns the query of the records matching the where, ordered by the order by.
+func listQuery[T any](ctx context.Context, where *Where, orderBy *OrderBy) (dataTx *gorm.DB, err error) {
+	dataTx = GetDBWithContext(ctx).Model(new(T))
+
+	whereClause, whereValues, errx := where.BuildFor(dataTx)
+	if errx != nil {
+		return nil, errx
+	}
+
+	if orderBy != nil {
+		if dataTx, err = orderBy.apply(dataTx, where); err != nil {
+			return nil, err
+		}
+	}
+	if whereClause != "" {
+		dataTx = dataTx.Where(whereClause, whereValues...)
+	}
+
+	return dataTx, nil
+}
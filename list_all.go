@@ -1,35 +1,32 @@
 package gormx
 
-import "fmt"
+import (
+	"context"
+)
 
 // ListALL lists all records.
 func ListALL[T any](where *Where, orderBy *OrderBy) (data []*T, err error) {
-	countTx := GetDB().Model(new(T))
-	dataTx := GetDB()
+	return ListALLCtx[T](context.Background(), where, orderBy)
+}
+
+// ListALLCtx lists all records with context.
+func ListALLCtx[T any](ctx context.Context, where *Where, orderBy *OrderBy) (data []*T, err error) {
+	dataTx := GetDBWithContext(ctx).Model(new(T))
 
 	if where != nil {
-		whereClause, whereValues, errx := where.Build()
+		whereClause, whereValues, errx := where.BuildFor(dataTx)
 		if errx != nil {
 			return nil, errx
 		}
 
 		if whereClause != "" {
-			countTx = countTx.Where(whereClause, whereValues...)
 			dataTx = dataTx.Where(whereClause, whereValues...)
 		}
 	}
 
 	if orderBy != nil {
-		for _, order := range *orderBy {
-			// fmt.Println("order by:", order.Key, order.IsDESC)
-			orderMod := "ASC"
-			if order.IsDESC {
-				orderMod = "DESC"
-			}
-
-			orderStr := fmt.Sprintf("%s %s", order.Key, orderMod)
-			countTx = countTx.Order(orderStr)
-			dataTx = dataTx.Order(orderStr)
+		if dataTx, err = orderBy.apply(dataTx, where); err != nil {
+			return nil, err
 		}
 	}
 
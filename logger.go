@@ -0,0 +1,127 @@
+package gormx
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"time"
+
+	zlogger "github.com/go-zoox/logger"
+	"gorm.io/gorm/logger"
+)
+
+// DefaultSlowThreshold is the default threshold of slow queries.
+const DefaultSlowThreshold = 200 * time.Millisecond
+
+// defaultLogWriter is the same writer as gorm's logger.Default.
+var defaultLogWriter = log.New(os.Stdout, "\r\n", log.LstdFlags)
+
+// zooxLogger is a gorm logger writing through github.com/go-zoox/logger.
+type zooxLogger struct {
+	logger.Config
+}
+
+// NewZooxLogger returns a gorm logger writing through github.com/go-zoox/logger.
+func NewZooxLogger(config logger.Config) logger.Interface {
+	return &zooxLogger{
+		Config: config,
+	}
+}
+
+// LogMode sets the log level.
+func (l *zooxLogger) LogMode(level logger.LogLevel) logger.Interface {
+	nl := *l
+	nl.LogLevel = level
+	return &nl
+}
+
+// Info logs info messages.
+func (l *zooxLogger) Info(ctx context.Context, msg string, data ...interface{}) {
+	if l.LogLevel >= logger.Info {
+		zlogger.Infof("[gormx] "+msg, data...)
+	}
+}
+
+// Warn logs warn messages.
+func (l *zooxLogger) Warn(ctx context.Context, msg string, data ...interface{}) {
+	if l.LogLevel >= logger.Warn {
+		zlogger.Warnf("[gormx] "+msg, data...)
+	}
+}
+
+// Error logs error messages.
+func (l *zooxLogger) Error(ctx context.Context, msg string, data ...interface{}) {
+	if l.LogLevel >= logger.Error {
+		zlogger.Errorf("[gormx] "+msg, data...)
+	}
+}
+
+// Trace logs the sql, as error if failed, as warn if slow, otherwise as info.
+func (l *zooxLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql string, rowsAffected int64), err error) {
+	if l.LogLevel <= logger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	ms := float64(elapsed.Nanoseconds()) / 1e6
+	switch {
+	case err != nil && l.LogLevel >= logger.Error && (!errors.Is(err, ErrRecordNotFound) || !l.IgnoreRecordNotFoundError):
+		sql, rows := fc()
+		zlogger.Errorf("[gormx][sql] %s [%.3fms] [rows:%d] %s", err, ms, rows, sql)
+	case l.SlowThreshold != 0 && elapsed > l.SlowThreshold && l.LogLevel >= logger.Warn:
+		sql, rows := fc()
+		zlogger.Warnf("[gormx][sql] SLOW SQL >= %v [%.3fms] [rows:%d] %s", l.SlowThreshold, ms, rows, sql)
+	case l.LogLevel == logger.Info:
+		sql, rows := fc()
+		zlogger.Infof("[gormx][sql] [%.3fms] [rows:%d] %s", ms, rows, sql)
+	}
+}
+
+// ParamsFilter hides the query parameters when ParameterizedQueries is on.
+func (l *zooxLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.ParameterizedQueries {
+		return sql, nil
+	}
+
+	return sql, params
+}
+
+// newLogger creates the gorm logger by the options.
+func newLogger(opt *LoadDBOptions) logger.Interface {
+	if opt.Logger != nil {
+		return opt.Logger
+	}
+
+	logLevel := opt.LogLevel
+	if logLevel == 0 {
+		logLevel = logger.Info
+		if opt.IsProd {
+			logLevel = logger.Error
+
+			// report slow queries in prod when the threshold is set explicitly
+			if opt.SlowThreshold > 0 {
+				logLevel = logger.Warn
+			}
+		}
+	}
+
+	slowThreshold := opt.SlowThreshold
+	if slowThreshold <= 0 {
+		slowThreshold = DefaultSlowThreshold
+	}
+
+	config := logger.Config{
+		SlowThreshold:             slowThreshold,
+		Colorful:                  !opt.UseZooxLogger,
+		IgnoreRecordNotFoundError: opt.IgnoreRecordNotFoundError,
+		ParameterizedQueries:      opt.RedactParams,
+		LogLevel:                  logLevel,
+	}
+
+	if opt.UseZooxLogger {
+		return NewZooxLogger(config)
+	}
+
+	return logger.New(defaultLogWriter, config)
+}
@@ -0,0 +1,43 @@
+package gormx
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+func TestNewLogger(t *testing.T) {
+	t.Run("Custom Logger", func(t *testing.T) {
+		custom := logger.Discard
+		if newLogger(&LoadDBOptions{Logger: custom}) != custom {
+			t.Error("Expected the custom logger to be used as is")
+		}
+	})
+
+	t.Run("Slow Threshold In Prod", func(t *testing.T) {
+		l := newLogger(&LoadDBOptions{IsProd: true, SlowThreshold: time.Second, UseZooxLogger: true}).(*zooxLogger)
+		if l.LogLevel != logger.Warn {
+			t.Errorf("Expected log level warn, got %d", l.LogLevel)
+		}
+		if l.SlowThreshold != time.Second {
+			t.Errorf("Expected slow threshold 1s, got %s", l.SlowThreshold)
+		}
+	})
+
+	t.Run("Explicit Log Level", func(t *testing.T) {
+		l := newLogger(&LoadDBOptions{LogLevel: logger.Silent, UseZooxLogger: true}).(*zooxLogger)
+		if l.LogLevel != logger.Silent {
+			t.Errorf("Expected log level silent, got %d", l.LogLevel)
+		}
+	})
+
+	t.Run("Redact Params", func(t *testing.T) {
+		l := newLogger(&LoadDBOptions{RedactParams: true, UseZooxLogger: true}).(*zooxLogger)
+		_, params := l.ParamsFilter(context.Background(), "SELECT ?", "secret")
+		if params != nil {
+			t.Errorf("Expected params to be redacted, got %v", params)
+		}
+	})
+}
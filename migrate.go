@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/go-zoox/logger"
+	"gorm.io/plugin/dbresolver"
 )
 
 // Migrate migrates the models to the database.
@@ -28,7 +29,8 @@ func Migrate() {
 		// fix:
 		//   ALTER TABLE v1_devops_dict DROP CONSTRAINT idx_v1_devops_dict_uuid;
 		//
-		return db.AutoMigrate(s)
+		// migrate on the primary, the table checks must not go to the replicas
+		return db.Clauses(dbresolver.Write).AutoMigrate(s)
 
 		// db.AutoMigrate(s)
 		// return nil
@@ -36,4 +38,11 @@ func Migrate() {
 	if err != nil {
 		panic(fmt.Errorf("failed to migrate: %s", err))
 	}
+
+	if isAuditLogEnabled() {
+		logger.Infof("[gormx][migrate] migrate: %s ...", AuditLog{}.TableName())
+		if err := MigrateAuditLog(); err != nil {
+			panic(fmt.Errorf("failed to migrate the audit log: %s", err))
+		}
+	}
 }
@@ -0,0 +1,95 @@
+package gormx
+
+import (
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// VersionColumn is the column of the optimistic lock version.
+const VersionColumn = "version"
+
+// OptimisticLock enables the optimistic locking of the model, embed it alongside ModelImpl.
+// Save, Update and QueryBuilder.UpdateVersioned check the version in the WHERE and increment it,
+// and return ErrStaleObject if the record has been changed since it was read.
+type OptimisticLock struct {
+	Version int64 `gorm:"column:version;not null;default:0" json:"version"`
+}
+
+// GetVersion returns the version.
+func (o *OptimisticLock) GetVersion() int64 {
+	return o.Version
+}
+
+// SetVersion sets the version.
+func (o *OptimisticLock) SetVersion(version int64) {
+	o.Version = version
+}
+
+// VersionedModel is implemented by the models with optimistic locking, e.g. by embedding OptimisticLock.
+// The version is stored in VersionColumn.
+type VersionedModel interface {
+	GetVersion() int64
+	SetVersion(version int64)
+}
+
+func isVersioned[T any]() bool {
+	_, ok := any(new(T)).(VersionedModel)
+	return ok
+}
+
+// saveOne saves the record, checking and incrementing the version if it is versioned.
+func saveOne[T any](tx *gorm.DB, one *T) error {
+	versioned, ok := any(one).(VersionedModel)
+	if !ok {
+		return tx.Save(one).Error
+	}
+
+	s, err := ParseSchema(one)
+	if err != nil {
+		return err
+	}
+
+	// new records are created, gorm.Save would create them too
+	if s.PrioritizedPrimaryField == nil {
+		return tx.Save(one).Error
+	}
+	if _, isZero := s.PrioritizedPrimaryField.ValueOf(tx.Statement.Context, reflect.ValueOf(one)); isZero {
+		return tx.Create(one).Error
+	}
+
+	current := versioned.GetVersion()
+	versioned.SetVersion(current + 1)
+
+	// gorm.Save falls back to insert if no rows are updated, so update all the columns instead
+	result := tx.Model(one).
+		Select("*").
+		Omit(clause.Associations).
+		Where(clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: VersionColumn}, Value: current}).
+		Updates(one)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrStaleObject
+	}
+	if result.Error != nil {
+		versioned.SetVersion(current)
+		return result.Error
+	}
+
+	return nil
+}
+
+// versionedUpdates returns the updates incrementing the version if T is versioned.
+func versionedUpdates[T any](updates map[string]any) map[string]any {
+	if !isVersioned[T]() {
+		return updates
+	}
+
+	versioned := make(map[string]any, len(updates)+1)
+	for k, v := range updates {
+		versioned[k] = v
+	}
+	versioned[VersionColumn] = gorm.Expr(VersionColumn + " + 1")
+
+	return versioned
+}
@@ -0,0 +1,89 @@
+package gormx
+
+import (
+	"errors"
+	"testing"
+)
+
+type TestVersionedItem struct {
+	ID   uint   `gorm:"primarykey"`
+	Name string `gorm:"column:name"`
+	OptimisticLock
+}
+
+func TestOptimisticLock(t *testing.T) {
+	ctx := openTestDB(t, "test_version", &TestVersionedItem{})
+
+	one := &TestVersionedItem{Name: "a"}
+	if err := SaveCtx(ctx, one); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// two editors read the same version
+	first, _ := RetrieveCtx[TestVersionedItem](ctx, one.ID)
+	second, _ := RetrieveCtx[TestVersionedItem](ctx, one.ID)
+
+	first.Name = "first"
+	if err := SaveCtx(ctx, first); err != nil || first.Version != 1 {
+		t.Fatalf("Expected the first save to succeed with version 1, got %d %v", first.Version, err)
+	}
+
+	second.Name = "second"
+	if err := SaveCtx(ctx, second); !IsStaleObjectError(err) {
+		t.Fatalf("Expected stale object error, got %v", err)
+	}
+	if second.Version != 0 {
+		t.Errorf("Expected the version to be restored, got %d", second.Version)
+	}
+
+	if err := UpdateCtx(ctx, one.ID, func(one *TestVersionedItem) { one.Name = "updated" }); err != nil {
+		t.Fatalf("Update failed: %v", err)
+	}
+
+	updated, err := FindOneByIDAndUpdateColumnsCtx[TestVersionedItem](ctx, one.ID, map[string]any{"name": "columns"})
+	if err != nil || updated.Version != 3 {
+		t.Fatalf("Expected version 3, got %+v %v", updated, err)
+	}
+
+	t.Run("QueryBuilder", func(t *testing.T) {
+		err := NewQueryCtx[TestVersionedItem](ctx).Where("id", one.ID).Update(map[string]interface{}{"name": "unchecked"})
+		if !errors.Is(err, ErrVersionRequired) {
+			t.Errorf("Expected the version to be required, got %v", err)
+		}
+
+		err = NewQueryCtx[TestVersionedItem](ctx).Where("id", one.ID).UpdateVersioned(2, map[string]interface{}{"name": "stale"})
+		if !IsStaleObjectError(err) {
+			t.Errorf("Expected stale object error, got %v", err)
+		}
+
+		err = NewQueryCtx[TestVersionedItem](ctx).Where("id", one.ID).UpdateVersioned(3, map[string]interface{}{"name": "fresh"})
+		if err != nil {
+			t.Fatalf("Update failed: %v", err)
+		}
+
+		fresh, _ := RetrieveCtx[TestVersionedItem](ctx, one.ID)
+		if fresh.Name != "fresh" || fresh.Version != 4 {
+			t.Errorf("Expected fresh with version 4, got %+v", fresh)
+		}
+
+		err = NewQueryCtx[TestVersionedItem](ctx).Where("id", one.ID).UpdateColumn(map[string]interface{}{"name": "unchecked"})
+		if !errors.Is(err, ErrVersionRequired) {
+			t.Errorf("Expected the version to be required by UpdateColumn, got %v", err)
+		}
+
+		err = NewQueryCtx[TestVersionedItem](ctx).Where("id", one.ID).UpdateColumnVersioned(3, map[string]interface{}{"name": "stale"})
+		if !IsStaleObjectError(err) {
+			t.Errorf("Expected stale object error of UpdateColumn, got %v", err)
+		}
+
+		err = NewQueryCtx[TestVersionedItem](ctx).Where("id", one.ID).UpdateColumnVersioned(4, map[string]interface{}{"name": "column"})
+		if err != nil {
+			t.Fatalf("UpdateColumn failed: %v", err)
+		}
+
+		fresh, _ = RetrieveCtx[TestVersionedItem](ctx, one.ID)
+		if fresh.Name != "column" || fresh.Version != 5 {
+			t.Errorf("Expected column with version 5, got %+v", fresh)
+		}
+	})
+}
@@ -3,13 +3,22 @@ package gormx
 import (
 	"fmt"
 	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// RankOrderKey is the order by key of the full text search relevance score, e.g. orderBy=_score:desc
+const RankOrderKey = "_score"
+
 // OrderByOne is a single order by.
 type OrderByOne struct {
 	Key    string
 	IsDESC bool
 
+	// IsQuoted => the key is a column name quoted by the dialect
+	IsQuoted bool
+
 	//
 	clause string
 }
@@ -129,3 +138,50 @@ func (w *OrderBy) Build() string {
 func (w *OrderBy) Reset() {
 	*w = []OrderByOne{}
 }
+
+// apply applies the order bys to the db,
+// RankOrderKey is ordered by the full text search relevance score of the where.
+func (w *OrderBy) apply(tx *gorm.DB, where *Where) (*gorm.DB, error) {
+	for _, order := range *w {
+		if order.IsQuoted {
+			tx = tx.Order(clause.OrderByColumn{Column: clause.Column{Name: order.Key}, Desc: order.IsDESC})
+			continue
+		}
+
+		if order.Key != RankOrderKey {
+			tx = tx.Order(order.Clause())
+			continue
+		}
+
+		if where == nil {
+			return nil, fmt.Errorf("order by %s requires a full text search", RankOrderKey)
+		}
+
+		expr, args, err := where.BuildRankFor(tx)
+		if err != nil {
+			return nil, err
+		}
+
+		orderMod := "ASC"
+		if order.IsDESC {
+			orderMod = "DESC"
+		}
+
+		tx = tx.Order(clause.OrderBy{
+			Expression: clause.Expr{SQL: fmt.Sprintf("%s %s", expr, orderMod), Vars: args, WithoutParentheses: true},
+		})
+	}
+
+	return tx, nil
+}
+
+// Clone returns a copy of the order bys.
+func (w *OrderBy) Clone() *OrderBy {
+	if w == nil {
+		return nil
+	}
+
+	c := make(OrderBy, len(*w))
+	copy(c, *w)
+	return &c
+}
@@ -0,0 +1,116 @@
+package gormx
+
+import "context"
+
+// PaginationPolicy is the pagination limits of the query string, see Params.UsePagination.
+type PaginationPolicy struct {
+	// DefaultPageSize is the page size (or cursor limit) if not specified,
+	// 0 means the one of DefaultPaginationPolicy.
+	DefaultPageSize uint
+	// MaxPageSize caps the page size (or cursor limit), 0 means unlimited.
+	MaxPageSize uint
+	// MaxPage caps the page, 0 means unlimited.
+	MaxPage uint
+	// SkipTotal does not count the total, which is slow on large tables,
+	// ListByParams detects the next page by reading one more record instead.
+	SkipTotal bool
+}
+
+// DefaultPaginationPolicy is the pagination policy of the params without UsePagination.
+var DefaultPaginationPolicy = &PaginationPolicy{
+	DefaultPageSize: 10,
+	MaxPageSize:     100,
+	MaxPage:         1000,
+}
+
+// getDefaultPageSize returns the default page size, falling back to DefaultPaginationPolicy,
+// then DefaultCursorLimit, so a policy without one does not list empty pages.
+func (p *PaginationPolicy) getDefaultPageSize() uint {
+	if p.DefaultPageSize > 0 {
+		return p.DefaultPageSize
+	}
+
+	if DefaultPaginationPolicy != nil && DefaultPaginationPolicy.DefaultPageSize > 0 {
+		return DefaultPaginationPolicy.DefaultPageSize
+	}
+
+	return DefaultCursorLimit
+}
+
+// ListResult is the standard list response.
+type ListResult[T any] struct {
+	Data []*T `json:"data"`
+	// Total is -1 if the total is skipped by the pagination policy
+	Total    int64 `json:"total"`
+	Page     uint  `json:"page"`
+	PageSize uint  `json:"page_size"`
+	HasNext  bool  `json:"has_next"`
+}
+
+// NewListResult returns the list result of the records of the page.
+func NewListResult[T any](data []*T, total int64, page, pageSize uint) *ListResult[T] {
+	if data == nil {
+		data = []*T{}
+	}
+
+	return &ListResult[T]{
+		Data:     data,
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+		HasNext:  total > int64(page*pageSize),
+	}
+}
+
+// ListByParams lists records of the list params as the standard list response.
+func ListByParams[T any](params *ListParams) (*ListResult[T], error) {
+	return ListByParamsCtx[T](context.Background(), params)
+}
+
+// ListByParamsCtx lists records of the list params as the standard list response with context.
+func ListByParamsCtx[T any](ctx context.Context, params *ListParams) (*ListResult[T], error) {
+	where := params.Where
+	if where == nil {
+		where = NewWhere()
+	}
+
+	page, pageSize := params.Page, params.PageSize
+	if page == 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = DefaultPaginationPolicy.getDefaultPageSize()
+	}
+
+	if !params.SkipTotal {
+		data, total, err := ListCtx[T](ctx, page, pageSize, where, params.OrderBy)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewListResult(data, total, page, pageSize), nil
+	}
+
+	dataTx, err := listQuery[T](ctx, where, params.OrderBy)
+	if err != nil {
+		return nil, err
+	}
+
+	var data []*T
+	err = dataTx.
+		Offset(int((page - 1) * pageSize)).
+		Limit(int(pageSize) + 1).
+		Find(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	result := NewListResult(data, -1, page, pageSize)
+	if len(data) > int(pageSize) {
+		result.Data = data[:pageSize]
+		result.HasNext = true
+	}
+
+	return result, nil
+}
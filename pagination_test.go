@@ -0,0 +1,47 @@
+package gormx
+
+import (
+	"testing"
+)
+
+type TestPaginationItem struct {
+	ID   uint   `gorm:"primarykey"`
+	Name string `gorm:"column:name"`
+}
+
+func TestListByParams(t *testing.T) {
+	ctx := openTestDB(t, "test_pagination", &TestPaginationItem{})
+
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		if err := GetDBWithContext(ctx).Create(&TestPaginationItem{Name: name}).Error; err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+	}
+
+	orderBy := NewOrderBy()
+	orderBy.Set("id", false)
+
+	result, err := ListByParamsCtx[TestPaginationItem](ctx, &ListParams{Page: 2, PageSize: 2, OrderBy: orderBy})
+	if err != nil {
+		t.Fatalf("ListByParams failed: %v", err)
+	}
+	if result.Total != 5 || !result.HasNext || len(result.Data) != 2 || result.Data[0].Name != "c" {
+		t.Errorf("Unexpected result: %+v", result)
+	}
+
+	result, err = ListByParamsCtx[TestPaginationItem](ctx, &ListParams{Page: 3, PageSize: 2, OrderBy: orderBy, SkipTotal: true})
+	if err != nil {
+		t.Fatalf("ListByParams failed: %v", err)
+	}
+	if result.Total != -1 || result.HasNext || len(result.Data) != 1 {
+		t.Errorf("Unexpected result without total: %+v", result)
+	}
+
+	result, err = ListByParamsCtx[TestPaginationItem](ctx, &ListParams{Page: 2, PageSize: 2, OrderBy: orderBy, SkipTotal: true})
+	if err != nil {
+		t.Fatalf("ListByParams failed: %v", err)
+	}
+	if !result.HasNext || len(result.Data) != 2 {
+		t.Errorf("Expected the next page without total: %+v", result)
+	}
+}
@@ -1,7 +1,9 @@
 package gormx
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/go-zoox/zoox"
@@ -12,6 +14,13 @@ type Params struct {
 	ctx *zoox.Context
 	//
 	page *Page
+	//
+	model any
+	//
+	pagination *PaginationPolicy
+	//
+	filterable []string
+	sortable   []string
 }
 
 // NewParams returns the params.
@@ -21,38 +30,95 @@ func NewParams(ctx *zoox.Context) *Params {
 	}
 }
 
-func (c *Params) parsePage() error {
+// UseModel sets the model of the params, whose fields are the only ones
+// allowed in the query string filters and order by of GetList.
+func (c *Params) UseModel(model any) *Params {
+	c.model = model
+	return c
+}
+
+// UseFilterable sets the fields allowed in the query string filters of GetList,
+// instead of the fields of the model.
+func (c *Params) UseFilterable(fields ...string) *Params {
+	c.filterable = fields
+	return c
+}
+
+// UseSortable sets the fields allowed in the query string order by of GetList,
+// instead of the fields of the model.
+func (c *Params) UseSortable(fields ...string) *Params {
+	c.sortable = fields
+	return c
+}
+
+// Context returns the context of the request, carrying the ID of the user of the
+// zoox context if any, so the records written with it are stamped, see WithUserID.
+func (c *Params) Context() context.Context {
+	ctx := c.ctx.Context()
+	if userID := userIDOf(c.ctx.User().Get()); userID != 0 {
+		ctx = WithUserID(ctx, userID)
+	}
+
+	return ctx
+}
+
+// UsePagination sets the pagination policy of the params, default is DefaultPaginationPolicy.
+func (c *Params) UsePagination(policy *PaginationPolicy) *Params {
+	c.pagination = policy
+	return c
+}
+
+func (c *Params) getPagination() *PaginationPolicy {
+	if c.pagination == nil {
+		return DefaultPaginationPolicy
+	}
+
+	return c.pagination
+}
+
+// parsePage parses the page of the query string,
+// the missing values fall back to the defaults if any, then the pagination policy.
+func (c *Params) parsePage(defaults *ListParamsDefault) error {
 	if c.page != nil {
 		return nil
 	}
 
-	c.page = &Page{}
-	if err := c.ctx.BindQuery(c.page); err != nil {
+	page := &Page{}
+	if err := c.ctx.BindQuery(page); err != nil {
 		return err
 	}
 
-	if c.page.PageSize == 0 {
-		c.page.PageSize = 10
+	policy := c.getPagination()
+
+	if page.PageSize <= 0 && defaults != nil {
+		page.PageSize = int64(defaults.PageSize)
+	}
+	if page.PageSize <= 0 {
+		page.PageSize = int64(policy.getDefaultPageSize())
 	}
 
-	if c.page.Page == 0 {
-		c.page.Page = 1
+	if page.Page <= 0 && defaults != nil {
+		page.Page = int64(defaults.Page)
+	}
+	if page.Page <= 0 {
+		page.Page = 1
 	}
 
-	if c.page.Page > 1000 {
-		c.page.Page = 1000
+	if policy.MaxPage > 0 && page.Page > int64(policy.MaxPage) {
+		page.Page = int64(policy.MaxPage)
 	}
 
-	if c.page.PageSize > 100 {
-		c.page.PageSize = 100
+	if policy.MaxPageSize > 0 && page.PageSize > int64(policy.MaxPageSize) {
+		page.PageSize = int64(policy.MaxPageSize)
 	}
 
+	c.page = page
 	return nil
 }
 
 // Page is the struct that wraps the basic fields.
 func (c *Params) Page() (uint, error) {
-	if err := c.parsePage(); err != nil {
+	if err := c.parsePage(nil); err != nil {
 		return 0, err
 	}
 
@@ -61,7 +127,7 @@ func (c *Params) Page() (uint, error) {
 
 // PageSize is the struct that wraps the basic fields.
 func (c *Params) PageSize() (uint, error) {
-	if err := c.parsePage(); err != nil {
+	if err := c.parsePage(nil); err != nil {
 		return 0, err
 	}
 
@@ -79,8 +145,40 @@ func (c *Params) ID() (uint, error) {
 }
 
 // Where is the struct that wraps the basic fields.
+// If the filters cannot be parsed, the where matches nothing, use ParseWhere to get the error.
 func (c *Params) Where() *Where {
-	var where Where
+	where, err := c.ParseWhere()
+	if err != nil {
+		// a rejected filter must not widen the query
+		where = NewWhere()
+		where.AddRaw("1 = 0")
+	}
+
+	return where
+}
+
+// ParseWhere parses the query string filters into a where, the grammar is:
+//
+//	key=value             key = value
+//	key=value:*           key contains value
+//	key=value:!           key <> value
+//	key=a,b:in            key IN (a, b)
+//	key=a,b:!in           key NOT IN (a, b)
+//	key=a&key=b           key IN (a, b), a repeated bracket key or q is invalid
+//	key[operator]=value   see filterOperators, e.g. age[gte]=18, deleted_at[null]=true
+//	filter={...}          nested AND/OR/NOT in JSON, see ParseFilter
+//
+// In the suffix grammar, a literal colon or comma is escaped as \: or \, and
+// values without a known suffix (e.g. 10:30) are literal. Bracket values are
+// taken literally except the commas of list operators.
+func (c *Params) ParseWhere() (*Where, error) {
+	return c.parseWhere()
+}
+
+// parseWhere parses the filters without the pagination params and the reserved keys,
+// e.g. cursor and limit of the keyset pagination, which may be columns elsewhere.
+func (c *Params) parseWhere(reserved ...string) (*Where, error) {
+	where := NewWhere()
 
 	whereObject := c.ctx.Queries()
 
@@ -91,30 +189,65 @@ func (c *Params) Where() *Where {
 	whereObject.Del("page-size")
 	whereObject.Del("order-by")
 
+	for _, key := range reserved {
+		whereObject.Del(key)
+	}
+
+	filter := whereObject.Get(FilterParam)
+	whereObject.Del(FilterParam)
+
+	query := c.ctx.Request.URL.Query()
 	for key, value := range whereObject.Iterator() {
-		if vs, ok := value.(string); ok {
-			if strings.Contains(vs, ":") {
-				vs := strings.Split(vs, ":")
-				pattern := vs[len(vs)-1]
-				value := strings.Join(vs[0:len(vs)-1], ":")
-				if pattern == "*" {
-					where.Set(key, value, &SetWhereOptions{IsFuzzy: true})
-				} else if pattern == "!" {
-					where.Set(key, value, &SetWhereOptions{IsNotEqual: true})
-				} else if pattern == "in" {
-					where.Set(key, strings.Split(value, ","), &SetWhereOptions{IsIn: true})
-				} else if pattern == "!in" {
-					where.Set(key, strings.Split(value, ","), &SetWhereOptions{IsNotIn: true})
-				}
-			} else {
-				where.Set(key, vs)
+		vs, ok := value.(string)
+		if values := query[key]; !ok || len(values) > 1 {
+			// a repeated key, e.g. status=a&status=b, is status IN (a, b)
+			if len(values) < 2 || key == "q" || bracketFilterPattern.MatchString(key) {
+				return where, &FieldValueError{Field: key, Value: strings.Join(values, ","), Type: "a single value"}
 			}
-		} else {
+
+			list := make([]string, 0, len(values))
+			for _, v := range values {
+				list = append(list, unescapeFilterValue(v))
+			}
+			where.Set(key, list, filterOperators["in"]())
+			continue
+		}
+
+		// the full text search keyword is taken literally, e.g. q=go:*
+		if key == "q" {
 			where.Set(key, vs)
+			continue
+		}
+
+		if matches := bracketFilterPattern.FindStringSubmatch(key); matches != nil {
+			// age[gte]=18&age[lt]=65 adds both
+			if err := addFilter(where, matches[1], matches[2], vs, false); err != nil {
+				return where, err
+			}
+
+			continue
+		}
+
+		value, operator := parseLegacyFilter(vs)
+		if operator == "in" || operator == "nin" {
+			where.Set(key, splitFilterList(value), filterOperators[operator]())
+		} else if operator == "eq" {
+			where.Set(key, unescapeFilterValue(value))
+		} else {
+			where.Set(key, unescapeFilterValue(value), filterOperators[operator]())
 		}
 	}
 
-	return &where
+	if fs, ok := filter.(string); ok && fs != "" {
+		sub, err := ParseFilter(fs)
+		if err != nil {
+			return where, err
+		}
+
+		where.And(sub)
+	}
+
+	return where, nil
 }
 
 // OrderBy is the struct that wraps the basic fields.
@@ -161,6 +294,8 @@ type ListParams struct {
 	PageSize uint
 	Where    *Where
 	OrderBy  *OrderBy
+	// SkipTotal is set by the pagination policy, see ListByParams
+	SkipTotal bool
 }
 
 // ListParamsDefault is the struct that wraps the basic fields.
@@ -179,22 +314,123 @@ func (c *Params) GetList(defaults ...*ListParamsDefault) (*ListParams, error) {
 	var listParams ListParams
 	var err error
 
-	listParams.Page, err = c.Page()
-	if err != nil {
+	if defaultsX != nil {
+		// parse again, the page may be parsed without the defaults
+		c.page = nil
+	}
+	if err := c.parsePage(defaultsX); err != nil {
 		return nil, fmt.Errorf("parse page error: %s", err)
-	} else if defaultsX != nil && listParams.Page == 0 && defaultsX.Page != 0 {
-		listParams.Page = defaultsX.Page
 	}
 
-	listParams.PageSize, err = c.PageSize()
+	listParams.Page = uint(c.page.Page)
+	listParams.PageSize = uint(c.page.PageSize)
+	listParams.SkipTotal = c.getPagination().SkipTotal
+
+	listParams.Where, err = c.ParseWhere()
+	if err != nil {
+		return nil, err
+	}
+	listParams.OrderBy = c.OrderBy()
+
+	if err := c.guard(listParams.Where, listParams.OrderBy); err != nil {
+		return nil, err
+	}
+
+	return &listParams, nil
+}
+
+// CursorListParams is the params of ListByCursor.
+type CursorListParams struct {
+	Cursor  string
+	Limit   uint
+	Where   *Where
+	OrderBy *OrderBy
+}
+
+// Cursor returns the cursor of the keyset pagination, empty for the first page.
+func (c *Params) Cursor() string {
+	return c.ctx.Query().Get("cursor").String()
+}
+
+// Limit returns the limit of the keyset pagination, limited by the pagination policy like the page size.
+func (c *Params) Limit() (uint, error) {
+	policy := c.getPagination()
+
+	limit := uint64(0)
+	if limitRaw := c.ctx.Query().Get("limit").String(); limitRaw != "" {
+		var err error
+		if limit, err = strconv.ParseUint(limitRaw, 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid limit: %s", limitRaw)
+		}
+	}
+
+	if limit == 0 {
+		limit = uint64(policy.getDefaultPageSize())
+	}
+
+	if policy.MaxPageSize > 0 && limit > uint64(policy.MaxPageSize) {
+		limit = uint64(policy.MaxPageSize)
+	}
+
+	return uint(limit), nil
+}
+
+// GetCursorList returns the params of ListByCursor, from ?cursor=&limit= and the filters and order by.
+func (c *Params) GetCursorList() (*CursorListParams, error) {
+	var err error
+	var listParams CursorListParams
+
+	listParams.Cursor = c.Cursor()
+	listParams.Limit, err = c.Limit()
 	if err != nil {
-		return nil, fmt.Errorf("parse page size error: %s", err)
-	} else if defaultsX != nil && listParams.PageSize == 0 && defaultsX.PageSize != 0 {
-		listParams.PageSize = defaultsX.PageSize
+		return nil, fmt.Errorf("parse limit error: %s", err)
 	}
 
-	listParams.Where = c.Where()
+	listParams.Where, err = c.parseWhere("cursor", "limit")
+	if err != nil {
+		return nil, err
+	}
 	listParams.OrderBy = c.OrderBy()
 
+	if err := c.guard(listParams.Where, listParams.OrderBy); err != nil {
+		return nil, err
+	}
+
 	return &listParams, nil
 }
+
+// guard rejects the filters and order by which are not valid column names,
+// or not allowed by the model if any, and quotes the others.
+// The filter values are converted to the field types of the model if any.
+func (c *Params) guard(where *Where, orderBy *OrderBy) error {
+	var whitelist *fieldWhitelist
+	if c.model != nil {
+		var err error
+		if whitelist, err = newFieldWhitelist(c.model); err != nil {
+			return err
+		}
+	}
+
+	if c.filterable != nil || c.sortable != nil {
+		whitelist = whitelist.override(c.filterable, c.sortable)
+	}
+
+	if err := whitelist.guardWhere(where); err != nil {
+		return err
+	}
+
+	if err := whitelist.guardOrderBy(orderBy); err != nil {
+		return err
+	}
+
+	if c.model == nil {
+		return nil
+	}
+
+	coercer, err := newValueCoercer(c.model)
+	if err != nil {
+		return err
+	}
+
+	return coercer.coerceWhere(where)
+}
@@ -0,0 +1,328 @@
+package gormx
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/go-zoox/zoox"
+)
+
+type TestParamsItem struct {
+	ID     uint   `gorm:"primarykey"`
+	Name   string `gorm:"column:name"`
+	Secret string `gorm:"column:secret"`
+}
+
+func (TestParamsItem) FilterableFields() []string {
+	return []string{"id", "name"}
+}
+
+// withParams runs fn with the params of a request with the given query string.
+func withParams(t *testing.T, query string, fn func(params *Params)) {
+	t.Helper()
+
+	called := false
+	app := zoox.New()
+	app.Get("/items", func(ctx *zoox.Context) {
+		called = true
+		fn(NewParams(ctx))
+	})
+
+	app.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/items?"+query, nil))
+	if !called {
+		t.Fatal("Expected the handler to be called")
+	}
+}
+
+func TestParams_GetListGuard(t *testing.T) {
+	t.Run("Quoted Columns", func(t *testing.T) {
+		withParams(t, "name=foo&orderBy=id:desc", func(params *Params) {
+			list, err := params.UseModel(&TestParamsItem{}).GetList()
+			if err != nil {
+				t.Fatalf("GetList failed: %v", err)
+			}
+
+			list.Where.Dialect = "postgres"
+			query, args, err := list.Where.Build()
+			if err != nil {
+				t.Fatalf("Build failed: %v", err)
+			}
+			assertWhere(t, query, args, `"name" = ?`, []interface{}{"foo"})
+
+			if !(*list.OrderBy)[0].IsQuoted {
+				t.Error("Expected order by to be quoted")
+			}
+		})
+	})
+
+	t.Run("Injection", func(t *testing.T) {
+		withParams(t, "name%3D1%20OR%201=1&x=1", func(params *Params) {
+			_, err := params.GetList()
+			if !IsUnknownFieldError(err) {
+				t.Errorf("Expected unknown field error, got %v", err)
+			}
+		})
+
+		withParams(t, "orderBy=id%3Bdrop:desc", func(params *Params) {
+			_, err := params.GetList()
+			if !IsUnknownFieldError(err) {
+				t.Errorf("Expected unknown field error, got %v", err)
+			}
+		})
+	})
+
+	t.Run("Not Filterable", func(t *testing.T) {
+		withParams(t, "secret=foo", func(params *Params) {
+			_, err := params.UseModel(&TestParamsItem{}).GetList()
+			if !IsUnknownFieldError(err) {
+				t.Errorf("Expected unknown field error, got %v", err)
+			}
+
+			var fieldErr *UnknownFieldError
+			if errors.As(err, &fieldErr) && fieldErr.Status() != http.StatusBadRequest {
+				t.Errorf("Expected status 400, got %d", fieldErr.Status())
+			}
+		})
+	})
+
+	t.Run("Sortable From Schema", func(t *testing.T) {
+		withParams(t, "orderBy=secret:asc", func(params *Params) {
+			if _, err := params.UseModel(&TestParamsItem{}).GetList(); err != nil {
+				t.Errorf("Expected schema columns to be sortable, got %v", err)
+			}
+		})
+	})
+}
+
+type TestParamsTypedItem struct {
+	ID        uint      `gorm:"primarykey"`
+	Age       int       `gorm:"column:age"`
+	Score     float64   `gorm:"column:score"`
+	Active    bool      `gorm:"column:active"`
+	Status    string    `gorm:"column:status"`
+	Token     string    `gorm:"column:token;type:uuid"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
+
+func (TestParamsTypedItem) FieldEnums() map[string][]string {
+	return map[string][]string{"status": {"draft", "published"}}
+}
+
+func TestParams_GetListCoerce(t *testing.T) {
+	t.Run("Typed Values", func(t *testing.T) {
+		query := "age=18&score=9.5&active=true&status=draft&created_at=2024-01-02&id=1,2:in&token=123e4567-e89b-12d3-a456-426614174000"
+		withParams(t, query, func(params *Params) {
+			list, err := params.UseModel(&TestParamsTypedItem{}).GetList()
+			if err != nil {
+				t.Fatalf("GetList failed: %v", err)
+			}
+
+			values := map[string]interface{}{}
+			for _, item := range list.Where.Items {
+				values[item.Key] = item.Value
+			}
+
+			expected := map[string]interface{}{
+				"age":        int64(18),
+				"score":      9.5,
+				"active":     true,
+				"status":     "draft",
+				"created_at": time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				"id":         []interface{}{uint64(1), uint64(2)},
+				"token":      "123e4567-e89b-12d3-a456-426614174000",
+			}
+			if !reflect.DeepEqual(values, expected) {
+				t.Errorf("Expected %v, got %v", expected, values)
+			}
+		})
+	})
+
+	t.Run("Invalid Values", func(t *testing.T) {
+		for _, query := range []string{"age=abc", "active=maybe", "status=deleted", "created_at=yesterday", "id=1,x:in", "token=abc"} {
+			withParams(t, query, func(params *Params) {
+				_, err := params.UseModel(&TestParamsTypedItem{}).GetList()
+				if !IsInvalidFieldValueError(err) {
+					t.Errorf("Expected invalid field value error of %s, got %v", query, err)
+				}
+
+				var fieldErr *FieldValueError
+				if errors.As(err, &fieldErr) && fieldErr.Status() != http.StatusBadRequest {
+					t.Errorf("Expected status 400, got %d", fieldErr.Status())
+				}
+			})
+		}
+	})
+}
+
+func TestParams_ParseWhere(t *testing.T) {
+	build := func(t *testing.T, where *Where) (string, []interface{}) {
+		t.Helper()
+
+		where.Dialect = "postgres"
+		// query string keys are iterated in random order
+		sort.SliceStable(where.Items, func(i, j int) bool {
+			return where.Items[i].Key < where.Items[j].Key
+		})
+
+		query, args, err := where.Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+
+		return query, args
+	}
+
+	t.Run("Brackets", func(t *testing.T) {
+		withParams(t, "age[gte]=18&age[lt]=65&deleted_at[null]=true&status[in]=a,b&name[like]=fo%25", func(params *Params) {
+			where, err := params.ParseWhere()
+			if err != nil {
+				t.Fatalf("ParseWhere failed: %v", err)
+			}
+
+			query, args := build(t, where)
+			if query != `age >= ? AND age < ? AND deleted_at IS NULL AND name ILIKE ? AND status in (?)` &&
+				query != `age < ? AND age >= ? AND deleted_at IS NULL AND name ILIKE ? AND status in (?)` {
+				t.Errorf("Unexpected query: %s", query)
+			}
+			if len(args) != 4 || args[2] != `%fo\%%` {
+				t.Errorf("Unexpected args: %v", args)
+			}
+		})
+	})
+
+	t.Run("Literal Colons", func(t *testing.T) {
+		withParams(t, `time=10:30&name=a\:*&tags=x\,y,z:in`, func(params *Params) {
+			where := params.Where()
+			if v, _ := where.Get("time"); v != "10:30" {
+				t.Errorf("Expected 10:30, got %v", v)
+			}
+			if v, _ := where.Get("name"); v != "a:*" {
+				t.Errorf("Expected a:*, got %v", v)
+			}
+			if v, _ := where.Get("tags"); !reflect.DeepEqual(v, []string{"x,y", "z"}) {
+				t.Errorf("Expected [x,y z], got %v", v)
+			}
+		})
+	})
+
+	t.Run("JSON Filter", func(t *testing.T) {
+		filter := url.QueryEscape(`{"or":[{"status":"draft"},{"age":{"gte":18,"lt":65}}],"not":{"deleted_at":null}}`)
+		withParams(t, "filter="+filter, func(params *Params) {
+			where, err := params.ParseWhere()
+			if err != nil {
+				t.Fatalf("ParseWhere failed: %v", err)
+			}
+
+			query, args := build(t, where)
+			expected := `(NOT (deleted_at IS NULL) AND ((status = ?) OR (age >= ? AND age < ?)))`
+			assertWhere(t, query, args, expected, []interface{}{"draft", "18", "65"})
+		})
+	})
+
+	t.Run("Repeated Keys", func(t *testing.T) {
+		withParams(t, "status=a&status=b", func(params *Params) {
+			where, err := params.ParseWhere()
+			if err != nil {
+				t.Fatalf("ParseWhere failed: %v", err)
+			}
+
+			query, args := build(t, where)
+			assertWhere(t, query, args, `status in (?)`, []interface{}{[]string{"a", "b"}})
+		})
+
+		withParams(t, "age[gte]=1&age[gte]=2", func(params *Params) {
+			if _, err := params.GetList(); !IsInvalidFieldValueError(err) {
+				t.Errorf("Expected invalid field value error, got %v", err)
+			}
+		})
+	})
+
+	t.Run("Cursor Params", func(t *testing.T) {
+		withParams(t, "cursor=abc&limit=5&name=foo", func(params *Params) {
+			// cursor and limit are only reserved by the keyset pagination
+			if v, _ := params.Where().Get("limit"); v != "5" {
+				t.Errorf("Expected the limit filter, got %v", v)
+			}
+
+			list, err := params.GetCursorList()
+			if err != nil {
+				t.Fatalf("GetCursorList failed: %v", err)
+			}
+			if _, ok := list.Where.Get("cursor"); ok || len(list.Where.Items) != 1 {
+				t.Errorf("Expected only the name filter, got %v", list.Where.Items)
+			}
+		})
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, query := range []string{"age[foo]=1", "age[between]=1", "filter=" + url.QueryEscape(`{"or":1}`), "filter=oops"} {
+			withParams(t, query, func(params *Params) {
+				if _, err := params.GetList(); !IsInvalidFilterError(err) {
+					t.Errorf("Expected invalid filter error of %s, got %v", query, err)
+				}
+
+				// the where of an invalid filter matches nothing
+				sql, args := build(t, params.Where())
+				assertWhere(t, sql, args, `(1 = 0)`, []interface{}{})
+			})
+		}
+	})
+}
+
+func TestParams_Pagination(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		withParams(t, "", func(params *Params) {
+			list, err := params.GetList(&ListParamsDefault{Page: 2, PageSize: 20})
+			if err != nil {
+				t.Fatalf("GetList failed: %v", err)
+			}
+
+			if list.Page != 2 || list.PageSize != 20 {
+				t.Errorf("Expected page 2 and page size 20, got %d and %d", list.Page, list.PageSize)
+			}
+		})
+	})
+
+	t.Run("Policy", func(t *testing.T) {
+		policy := &PaginationPolicy{DefaultPageSize: 5, MaxPageSize: 50, MaxPage: 3, SkipTotal: true}
+
+		withParams(t, "page=10&pageSize=500", func(params *Params) {
+			list, err := params.UsePagination(policy).GetList()
+			if err != nil {
+				t.Fatalf("GetList failed: %v", err)
+			}
+
+			if list.Page != 3 || list.PageSize != 50 || !list.SkipTotal {
+				t.Errorf("Expected page 3, page size 50 and skip total, got %+v", list)
+			}
+		})
+
+		withParams(t, "", func(params *Params) {
+			if pageSize, _ := params.UsePagination(policy).PageSize(); pageSize != 5 {
+				t.Errorf("Expected page size 5, got %d", pageSize)
+			}
+		})
+	})
+
+	t.Run("Policy Without Default Page Size", func(t *testing.T) {
+		policy := &PaginationPolicy{MaxPageSize: 50, SkipTotal: true}
+
+		withParams(t, "", func(params *Params) {
+			if pageSize, _ := params.UsePagination(policy).PageSize(); pageSize != DefaultPaginationPolicy.DefaultPageSize {
+				t.Errorf("Expected the default page size, got %d", pageSize)
+			}
+		})
+
+		withParams(t, "", func(params *Params) {
+			if limit, _ := params.UsePagination(policy).Limit(); limit != DefaultPaginationPolicy.DefaultPageSize {
+				t.Errorf("Expected the default limit, got %d", limit)
+			}
+		})
+	})
+}
@@ -29,6 +29,7 @@ func Register(name string, m Model) {
 
 	logger.Infof("[gormx][register] model: %s", name)
 	model.Register(name, m)
+	forgetUnregisteredModels()
 }
 
 // Get returns the model by the given id.
@@ -0,0 +1,147 @@
+package gormx
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// ReplicaPolicy is the routing policy of the read replicas.
+type ReplicaPolicy string
+
+const (
+	// ReplicaPolicyRoundRobin routes the reads to the replicas in turn.
+	ReplicaPolicyRoundRobin ReplicaPolicy = "round-robin"
+	// ReplicaPolicyRandom routes the reads to a random replica.
+	ReplicaPolicyRandom ReplicaPolicy = "random"
+	// ReplicaPolicyWeighted routes the reads to a random replica according to ReplicaWeights.
+	ReplicaPolicyWeighted ReplicaPolicy = "weighted"
+)
+
+type primaryContextKey struct{}
+
+// WithPrimary returns a copy of ctx that routes the reads of the ...Ctx helpers
+// to the primary, for read-after-write consistency.
+func WithPrimary(ctx context.Context) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithValue(ctx, primaryContextKey{}, true)
+}
+
+// forWrite returns ctx routed to the primary for the helpers which read a record to write it,
+// since a lagging replica may return a stale record, or none if it was just created.
+func forWrite(ctx context.Context) context.Context {
+	return WithPrimary(ctx)
+}
+
+// IsPrimaryContext returns true if ctx routes the reads to the primary.
+func IsPrimaryContext(ctx context.Context) bool {
+	if ctx == nil {
+		return false
+	}
+
+	v, _ := ctx.Value(primaryContextKey{}).(bool)
+	return v
+}
+
+func useReplicas(db *gorm.DB, engine string, opt *LoadDBOptions) error {
+	replicas := []gorm.Dialector{}
+	for _, dsn := range opt.Replicas {
+		dialector, err := openDialector(engine, dsn)
+		if err != nil {
+			return err
+		}
+
+		replicas = append(replicas, dialector)
+	}
+
+	policy, err := newReplicaPolicy(opt)
+	if err != nil {
+		return err
+	}
+
+	resolver := dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   policy,
+	})
+	if opt.MaxOpenConns > 0 {
+		resolver = resolver.SetMaxOpenConns(opt.MaxOpenConns)
+	}
+	if opt.MaxIdleConns > 0 {
+		resolver = resolver.SetMaxIdleConns(opt.MaxIdleConns)
+	}
+	if opt.ConnMaxLifetime > 0 {
+		resolver = resolver.SetConnMaxLifetime(opt.ConnMaxLifetime)
+	}
+	if opt.ConnMaxIdleTime > 0 {
+		resolver = resolver.SetConnMaxIdleTime(opt.ConnMaxIdleTime)
+	}
+
+	return db.Use(resolver)
+}
+
+func newReplicaPolicy(opt *LoadDBOptions) (dbresolver.Policy, error) {
+	switch opt.ReplicaPolicy {
+	case "", ReplicaPolicyRoundRobin:
+		return dbresolver.StrictRoundRobinPolicy(), nil
+	case ReplicaPolicyRandom:
+		return dbresolver.RandomPolicy{}, nil
+	case ReplicaPolicyWeighted:
+		if len(opt.ReplicaWeights) != len(opt.Replicas) {
+			return nil, fmt.Errorf("replica weights(%d) must match replicas(%d)", len(opt.ReplicaWeights), len(opt.Replicas))
+		}
+
+		return newWeightedPolicy(opt.ReplicaWeights)
+	default:
+		return nil, fmt.Errorf("unknown replica policy: %s", opt.ReplicaPolicy)
+	}
+}
+
+// weightedPolicy picks a replica randomly in proportion to its weight.
+type weightedPolicy struct {
+	weights []int
+	total   int
+}
+
+func newWeightedPolicy(weights []int) (*weightedPolicy, error) {
+	total := 0
+	for _, w := range weights {
+		if w < 0 {
+			return nil, fmt.Errorf("replica weight must not be negative: %d", w)
+		}
+
+		total += w
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("replica weights must not be all zero")
+	}
+
+	return &weightedPolicy{
+		weights: weights,
+		total:   total,
+	}, nil
+}
+
+// Resolve implements dbresolver.Policy.
+func (p *weightedPolicy) Resolve(connPools []gorm.ConnPool) gorm.ConnPool {
+	n := rand.Intn(p.total)
+	for i, w := range p.weights {
+		if i >= len(connPools) {
+			break
+		}
+
+		if n < w {
+			return connPools[i]
+		}
+
+		n -= w
+	}
+
+	return connPools[len(connPools)-1]
+}
@@ -0,0 +1,88 @@
+package gormx
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+type TestReplicaItem struct {
+	ID   uint   `gorm:"primarykey"`
+	Name string `gorm:"column:name"`
+}
+
+func TestReplicaRouting(t *testing.T) {
+	dir := t.TempDir()
+	primary := filepath.Join(dir, "primary.db")
+	replica := filepath.Join(dir, "replica.db")
+
+	// the replica is a separate database here, so writes are not replicated
+	replicaDB, err := open("sqlite", replica, func(opt *LoadDBOptions) { opt.IsProd = true })
+	if err != nil {
+		t.Fatalf("Opening replica failed: %v", err)
+	}
+	if err := replicaDB.AutoMigrate(&TestReplicaItem{}); err != nil {
+		t.Fatalf("Failed to migrate replica: %v", err)
+	}
+
+	err = LoadNamedDB("test_replica", "sqlite", primary, func(opt *LoadDBOptions) {
+		opt.IsProd = true
+		opt.Replicas = []string{replica}
+	})
+	if err != nil {
+		t.Fatalf("LoadNamedDB failed: %v", err)
+	}
+	if err := GetNamedDB("test_replica").Clauses(dbresolver.Write).AutoMigrate(&TestReplicaItem{}); err != nil {
+		t.Fatalf("Failed to migrate primary: %v", err)
+	}
+
+	ctx := WithConnection(context.Background(), "test_replica")
+	if _, err := CreateCtx(ctx, &TestReplicaItem{Name: "one"}); err != nil {
+		t.Fatalf("CreateCtx failed: %v", err)
+	}
+
+	count, err := CountCtx[TestReplicaItem](ctx, NewWhere())
+	if err != nil {
+		t.Fatalf("CountCtx failed: %v", err)
+	}
+	if count != 0 {
+		t.Errorf("Expected reads to go to the replica (0 rows), got %d", count)
+	}
+
+	count, err = CountCtx[TestReplicaItem](WithPrimary(ctx), NewWhere())
+	if err != nil {
+		t.Fatalf("CountCtx on primary failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected WithPrimary to read the primary (1 row), got %d", count)
+	}
+
+	count, err = NewQueryCtx[TestReplicaItem](ctx).UsePrimary().Count()
+	if err != nil {
+		t.Fatalf("UsePrimary count failed: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected UsePrimary to read the primary (1 row), got %d", count)
+	}
+}
+
+func TestWeightedPolicy(t *testing.T) {
+	if _, err := newWeightedPolicy([]int{0, 0}); err == nil {
+		t.Error("Expected error for all zero weights")
+	}
+
+	policy, err := newWeightedPolicy([]int{0, 1})
+	if err != nil {
+		t.Fatalf("newWeightedPolicy failed: %v", err)
+	}
+
+	pools := []gorm.ConnPool{&gorm.PreparedStmtDB{}, &gorm.PreparedStmtDB{}}
+	for i := 0; i < 10; i++ {
+		if policy.Resolve(pools) != pools[1] {
+			t.Fatal("Expected the replica with zero weight never to be picked")
+		}
+	}
+}
@@ -1,9 +1,16 @@
 package gormx
 
+import "context"
+
 // Retrieve retrieves a record.
 func Retrieve[T any](id uint) (*T, error) {
+	return RetrieveCtx[T](context.Background(), id)
+}
+
+// RetrieveCtx retrieves a record with context.
+func RetrieveCtx[T any](ctx context.Context, id uint) (*T, error) {
 	var f T
-	if err := GetDB().First(&f, id).Error; err != nil {
+	if err := GetDBWithContext(ctx).First(&f, id).Error; err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,15 @@
 package gormx
 
+import "context"
+
 // Save saves a record.
+// If the record is versioned, see OptimisticLock, ErrStaleObject is returned
+// if it has been changed since it was read.
 func Save[T any](one *T) error {
-	return GetDB().Save(one).Error
+	return SaveCtx(context.Background(), one)
+}
+
+// SaveCtx saves a record with context.
+func SaveCtx[T any](ctx context.Context, one *T) error {
+	return saveOne(GetDBWithContext(ctx), one)
 }
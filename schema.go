@@ -0,0 +1,20 @@
+package gormx
+
+import (
+	"sync"
+
+	"gorm.io/gorm/schema"
+)
+
+var schemaCache = &sync.Map{}
+
+// ParseSchema parses the gorm schema of the model,
+// with the naming strategy of the default connection if loaded.
+func ParseSchema(model any) (*schema.Schema, error) {
+	var namer schema.Namer = schema.NamingStrategy{SingularTable: true}
+	if db != nil && db.NamingStrategy != nil {
+		namer = db.NamingStrategy
+	}
+
+	return schema.Parse(model, schemaCache, namer)
+}
@@ -0,0 +1,150 @@
+package gormx
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+var deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+
+// deletedAtField returns the soft delete field of the model T, e.g. DeletedAt of ModelImpl.
+func deletedAtField[T any]() (*schema.Field, error) {
+	s, err := ParseSchema(new(T))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, field := range s.Fields {
+		if field.DBName != "" && field.FieldType == deletedAtType {
+			return field, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrNotSoftDeletable, s.Name)
+}
+
+// trashedCondition is the condition of the soft deleted records.
+func trashedCondition(field *schema.Field) clause.Expression {
+	return clause.Expr{
+		SQL:  "? IS NOT NULL",
+		Vars: []interface{}{clause.Column{Table: clause.CurrentTable, Name: field.DBName}},
+	}
+}
+
+// notTrashedCondition is the condition of the records which are not soft deleted.
+func notTrashedCondition(field *schema.Field) clause.Expression {
+	return clause.Expr{
+		SQL:  "? IS NULL",
+		Vars: []interface{}{clause.Column{Table: clause.CurrentTable, Name: field.DBName}},
+	}
+}
+
+// Restore restores the soft deleted record by id.
+func Restore[T any](id uint) error {
+	return RestoreCtx[T](context.Background(), id)
+}
+
+// RestoreCtx restores the soft deleted record by id with context,
+// returns ErrRecordNotFound if there is no such soft deleted record.
+func RestoreCtx[T any](ctx context.Context, id uint) error {
+	field, err := deletedAtField[T]()
+	if err != nil {
+		return err
+	}
+
+	result := GetDBWithContext(ctx).
+		Model(new(T)).
+		Unscoped().
+		Where(trashedCondition(field)).
+		Where(clause.Eq{Column: clause.PrimaryColumn, Value: id}).
+		Updates(versionedUpdates[T](map[string]any{field.DBName: nil}))
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// ListTrashed lists the soft deleted records.
+func ListTrashed[T any](page, pageSize uint, where *Where, orderBy *OrderBy) (data []*T, total int64, err error) {
+	return ListTrashedCtx[T](context.Background(), page, pageSize, where, orderBy)
+}
+
+// ListTrashedCtx lists the soft deleted records with context.
+func ListTrashedCtx[T any](ctx context.Context, page, pageSize uint, where *Where, orderBy *OrderBy) (data []*T, total int64, err error) {
+	field, err := deletedAtField[T]()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if where == nil {
+		where = NewWhere()
+	}
+
+	dataTx, err := listQuery[T](ctx, where, orderBy)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = dataTx.
+		Unscoped().
+		Where(trashedCondition(field)).
+		Count(&total).
+		Offset(int((page - 1) * pageSize)).
+		Limit(int(pageSize)).
+		Find(&data).
+		Error
+
+	return
+}
+
+// ForceDelete deletes the record by id permanently, whether it is soft deleted or not.
+func ForceDelete[T any](id uint) error {
+	return ForceDeleteCtx[T](context.Background(), id)
+}
+
+// ForceDeleteCtx deletes the record by id permanently with context.
+func ForceDeleteCtx[T any](ctx context.Context, id uint) error {
+	ctx = forWrite(ctx)
+
+	var f T
+	if err := GetDBWithContext(ctx).Unscoped().First(&f, id).Error; err != nil {
+		return err
+	}
+
+	return GetDBWithContext(ctx).Unscoped().Delete(&f).Error
+}
+
+// PurgeDeletedBefore deletes the records soft deleted before the time permanently,
+// returns the number of purged records.
+func PurgeDeletedBefore[T any](before time.Time) (int64, error) {
+	return PurgeDeletedBeforeCtx[T](context.Background(), before)
+}
+
+// PurgeDeletedBeforeCtx deletes the records soft deleted before the time permanently with context.
+func PurgeDeletedBeforeCtx[T any](ctx context.Context, before time.Time) (int64, error) {
+	field, err := deletedAtField[T]()
+	if err != nil {
+		return 0, err
+	}
+
+	result := GetDBWithContext(ctx).
+		Unscoped().
+		Where(clause.Lt{Column: clause.Column{Table: clause.CurrentTable, Name: field.DBName}, Value: before}).
+		Delete(new(T))
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,117 @@
+package gormx
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type TestTrashedItem struct {
+	ModelImpl
+	Name string `gorm:"column:name"`
+}
+
+func TestSoftDelete(t *testing.T) {
+	ctx := openTestDB(t, "test_soft_delete", &TestTrashedItem{}, &TestAuditedItem{})
+
+	ids := []uint{}
+	for _, name := range []string{"a", "b", "c"} {
+		one, err := CreateCtx(ctx, &TestTrashedItem{Name: name})
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		ids = append(ids, one.ID)
+	}
+
+	for _, id := range ids[:2] {
+		if err := DeleteOneByIDCtx[TestTrashedItem](ctx, id); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+	}
+
+	trashed, total, err := ListTrashedCtx[TestTrashedItem](ctx, 1, 10, nil, &OrderBy{{Key: "id"}})
+	if err != nil || total != 2 || len(trashed) != 2 || trashed[0].Name != "a" {
+		t.Fatalf("Expected the 2 trashed records, got %d %v", total, err)
+	}
+
+	if count, _ := NewQueryCtx[TestTrashedItem](ctx).Count(); count != 1 {
+		t.Errorf("Expected 1 record, got %d", count)
+	}
+	if count, _ := NewQueryCtx[TestTrashedItem](ctx).WithTrashed().Count(); count != 3 {
+		t.Errorf("Expected 3 records with trashed, got %d", count)
+	}
+	if count, _ := NewQueryCtx[TestTrashedItem](ctx).OnlyTrashed().Where("name", "b").Count(); count != 1 {
+		t.Errorf("Expected 1 trashed record of b, got %d", count)
+	}
+
+	if err := RestoreCtx[TestTrashedItem](ctx, ids[0]); err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if one, err := RetrieveCtx[TestTrashedItem](ctx, ids[0]); err != nil || one.Name != "a" {
+		t.Errorf("Expected the restored record, got %v", err)
+	}
+	if err := RestoreCtx[TestTrashedItem](ctx, ids[2]); !IsRecordNotFoundError(err) {
+		t.Errorf("Expected restoring a live record not found, got %v", err)
+	}
+
+	if err := ForceDeleteCtx[TestTrashedItem](ctx, ids[2]); err != nil {
+		t.Fatalf("ForceDelete failed: %v", err)
+	}
+	if count, _ := NewQueryCtx[TestTrashedItem](ctx).WithTrashed().Count(); count != 2 {
+		t.Errorf("Expected 2 records after force delete, got %d", count)
+	}
+
+	if purged, err := PurgeDeletedBeforeCtx[TestTrashedItem](ctx, time.Now().Add(-time.Hour)); err != nil || purged != 0 {
+		t.Errorf("Expected nothing purged before an hour ago, got %d %v", purged, err)
+	}
+	if purged, err := PurgeDeletedBeforeCtx[TestTrashedItem](ctx, time.Now().Add(time.Hour)); err != nil || purged != 1 {
+		t.Errorf("Expected 1 purged, got %d %v", purged, err)
+	}
+	if count, _ := NewQueryCtx[TestTrashedItem](ctx).WithTrashed().Count(); count != 1 {
+		t.Errorf("Expected 1 record after purge, got %d", count)
+	}
+
+	t.Run("Query Delete", func(t *testing.T) {
+		for _, name := range []string{"c", "d"} {
+			if _, err := CreateCtx(ctx, &TestTrashedItem{Name: name}); err != nil {
+				t.Fatalf("Create failed: %v", err)
+			}
+		}
+
+		// Delete of a WithTrashed query is still a soft delete
+		if err := NewQueryCtx[TestTrashedItem](ctx).WithTrashed().Where("name", "c").Delete(); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		if count, _ := NewQueryCtx[TestTrashedItem](ctx).OnlyTrashed().Where("name", "c").Count(); count != 1 {
+			t.Errorf("Expected c soft deleted, got %d trashed", count)
+		}
+
+		if err := NewQueryCtx[TestTrashedItem](ctx).OnlyTrashed().Where("name", "c").ForceDelete(); err != nil {
+			t.Fatalf("ForceDelete failed: %v", err)
+		}
+		if count, _ := NewQueryCtx[TestTrashedItem](ctx).WithTrashed().Where("name", "c").Count(); count != 0 {
+			t.Errorf("Expected c deleted permanently, got %d", count)
+		}
+
+		// ForceDelete without a trashed scope keeps the soft deleted records out
+		if err := NewQueryCtx[TestTrashedItem](ctx).Where("name", "d").Delete(); err != nil {
+			t.Fatalf("Delete failed: %v", err)
+		}
+		if err := NewQueryCtx[TestTrashedItem](ctx).Where("name", "d").ForceDelete(); err != nil {
+			t.Fatalf("ForceDelete failed: %v", err)
+		}
+		if count, _ := NewQueryCtx[TestTrashedItem](ctx).OnlyTrashed().Where("name", "d").Count(); count != 1 {
+			t.Errorf("Expected the soft deleted d kept, got %d", count)
+		}
+	})
+
+	t.Run("Not Soft Deletable", func(t *testing.T) {
+		if err := RestoreCtx[TestAuditedItem](ctx, 1); !errors.Is(err, ErrNotSoftDeletable) {
+			t.Errorf("Expected ErrNotSoftDeletable, got %v", err)
+		}
+
+		if count, err := NewQueryCtx[TestAuditedItem](ctx).OnlyTrashed().Count(); err != nil || count != 0 {
+			t.Errorf("Expected no trashed records, got %d %v", count, err)
+		}
+	})
+}
@@ -1,9 +1,16 @@
 package gormx
 
+import "context"
+
 // SQL finds one record by id or create a new one.
 func SQL[T any](sql string, values ...any) (*T, error) {
+	return SQLCtx[T](context.Background(), sql, values...)
+}
+
+// SQLCtx runs the raw sql and scans the result with context.
+func SQLCtx[T any](ctx context.Context, sql string, values ...any) (*T, error) {
 	var f T
-	if err := GetDB().Raw(sql, values...).Scan(&f).Error; err != nil {
+	if err := GetDBWithContext(ctx).Raw(sql, values...).Scan(&f).Error; err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,50 @@
+package gormx
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type transactionContextKey struct{}
+
+// WithTransaction returns a copy of ctx bound to the transaction,
+// so the ...Ctx helpers called with it run in the transaction.
+func WithTransaction(ctx context.Context, tx *gorm.DB) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithValue(ctx, transactionContextKey{}, tx)
+}
+
+// TransactionFromContext returns the transaction bound to ctx, or nil if there is none.
+func TransactionFromContext(ctx context.Context) *gorm.DB {
+	if ctx == nil {
+		return nil
+	}
+
+	tx, _ := ctx.Value(transactionContextKey{}).(*gorm.DB)
+	return tx
+}
+
+// Transaction runs fn in a transaction of the connection of ctx, which is
+// committed if fn returns nil, or rolled back otherwise. The ctx of fn is bound
+// to the transaction, and a nested Transaction runs in a savepoint of it.
+func Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return GetDBWithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(WithTransaction(ctx, tx))
+	})
+}
+
+// lockForUpdate locks the selected rows until the end of the transaction
+// by SELECT ... FOR UPDATE, SQLite does not support it but serializes the writes.
+func lockForUpdate(tx *gorm.DB) *gorm.DB {
+	switch tx.Dialector.Name() {
+	case "postgres", "mysql":
+		return tx.Clauses(clause.Locking{Strength: "UPDATE"})
+	default:
+		return tx
+	}
+}
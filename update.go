@@ -1,15 +1,15 @@
 package gormx
 
+import "context"
+
 // Update updates a record.
+// The record is locked by SELECT ... FOR UPDATE (where supported) and saved in a transaction.
 func Update[T any](id uint, uc func(*T)) (err error) {
-	var f T
-	err = GetDB().First(&f, id).Error
-	if err != nil {
-		return
-	}
-
-	uc(&f)
+	return UpdateCtx(context.Background(), id, uc)
+}
 
-	err = GetDB().Save(&f).Error
+// UpdateCtx updates a record with context.
+func UpdateCtx[T any](ctx context.Context, id uint, uc func(*T)) (err error) {
+	_, err = findOneAndUpdate(ctx, findByIDForUpdate[T](id), saveCallback(uc))
 	return
 }
@@ -0,0 +1,126 @@
+package gormx
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// findOrCreateAttempts is the attempts of FindOneOrCreate when the record is created concurrently.
+const findOrCreateAttempts = 3
+
+// Upsert inserts the record, or updates the update columns of the existing record
+// conflicting on the conflict columns, by ON CONFLICT on Postgres and SQLite,
+// or ON DUPLICATE KEY UPDATE on MySQL (which ignores the conflict columns).
+// The existing record is left as it is if the update columns are empty,
+// and it is read again by the conflict columns and returned instead of one,
+// or ErrDuplicatedKey is returned if there are no conflict columns.
+func Upsert[T any](one *T, conflictColumns []string, updateColumns []string) (*T, error) {
+	return UpsertCtx(context.Background(), one, conflictColumns, updateColumns)
+}
+
+// UpsertCtx inserts or updates the record with context.
+func UpsertCtx[T any](ctx context.Context, one *T, conflictColumns []string, updateColumns []string) (*T, error) {
+	onConflict := clause.OnConflict{}
+	for _, column := range conflictColumns {
+		onConflict.Columns = append(onConflict.Columns, clause.Column{Name: column})
+	}
+
+	if len(updateColumns) == 0 {
+		onConflict.DoNothing = true
+	} else {
+		onConflict.DoUpdates = clause.AssignmentColumns(updateColumns)
+	}
+
+	result := GetDBWithContext(ctx).Clauses(onConflict).Create(one)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if onConflict.DoNothing && result.RowsAffected == 0 {
+		return findConflicting(ctx, one, conflictColumns)
+	}
+
+	return one, nil
+}
+
+// findConflicting finds the existing record conflicting with one on the conflict columns.
+func findConflicting[T any](ctx context.Context, one *T, conflictColumns []string) (*T, error) {
+	if len(conflictColumns) == 0 {
+		return nil, ErrDuplicatedKey
+	}
+
+	s, err := ParseSchema(one)
+	if err != nil {
+		return nil, err
+	}
+
+	where := map[string]any{}
+	for _, column := range conflictColumns {
+		field := s.LookUpField(column)
+		if field == nil || field.DBName == "" {
+			return nil, fmt.Errorf("conflict column %s is not a column of %s", column, s.Name)
+		}
+
+		where[field.DBName], _ = field.ValueOf(ctx, reflect.ValueOf(one).Elem())
+	}
+
+	var existing T
+	if err := GetDBWithContext(forWrite(ctx)).Unscoped().Where(where).First(&existing).Error; err != nil {
+		return nil, err
+	}
+
+	return &existing, nil
+}
+
+// findOneOrCreate finds the record, or creates it by the callback, which is atomic
+// if the where is covered by a unique index: the insert does nothing on conflict,
+// then the record created concurrently is found again.
+func findOneOrCreate[T any](ctx context.Context, find func(ctx context.Context) (*T, error), callback func(*T)) (*T, error) {
+	ctx = forWrite(ctx)
+
+	var err error
+	for attempt := 0; attempt < findOrCreateAttempts; attempt++ {
+		var f *T
+		if f, err = find(ctx); err == nil {
+			return f, nil
+		} else if !IsRecordNotFoundError(err) {
+			return nil, err
+		}
+
+		var tmp T
+		callback(&tmp)
+
+		d := GetDBWithContext(ctx)
+		result := d.Clauses(clause.OnConflict{DoNothing: true}).Create(&tmp)
+		if result.Error == nil && result.RowsAffected > 0 {
+			return &tmp, nil
+		}
+
+		if result.Error != nil && !IsDuplicatedKeyError(translateError(d, result.Error)) {
+			return nil, result.Error
+		}
+
+		// created concurrently, find it again
+		err = ErrDuplicatedKey
+	}
+
+	return nil, err
+}
+
+// translateError translates the driver error of db, e.g. into ErrDuplicatedKey,
+// without the GORM TranslateError config, which changes the errors of every caller.
+func translateError(db *gorm.DB, err error) error {
+	if err == nil || db.Config == nil {
+		return err
+	}
+
+	if translator, ok := db.Dialector.(gorm.ErrorTranslator); ok {
+		return translator.Translate(err)
+	}
+
+	return err
+}
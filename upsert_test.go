@@ -0,0 +1,88 @@
+package gormx
+
+import (
+	"context"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type TestUpsertItem struct {
+	ID    uint   `gorm:"primarykey"`
+	Email string `gorm:"column:email;uniqueIndex"`
+	Name  string `gorm:"column:name"`
+}
+
+func TestUpsert(t *testing.T) {
+	ctx := openTestDB(t, "test_upsert", &TestUpsertItem{})
+
+	if _, err := UpsertCtx(ctx, &TestUpsertItem{Email: "a@x.com", Name: "a"}, []string{"email"}, []string{"name"}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	if _, err := UpsertCtx(ctx, &TestUpsertItem{Email: "a@x.com", Name: "b"}, []string{"email"}, []string{"name"}); err != nil {
+		t.Fatalf("Upsert failed: %v", err)
+	}
+	existing, err := UpsertCtx(ctx, &TestUpsertItem{Email: "a@x.com", Name: "c"}, []string{"email"}, nil)
+	if err != nil {
+		t.Fatalf("Upsert do nothing failed: %v", err)
+	}
+	if existing.ID == 0 || existing.Name != "b" {
+		t.Errorf("Expected the existing record, got %+v", existing)
+	}
+	if _, err := UpsertCtx(ctx, &TestUpsertItem{Email: "a@x.com", Name: "c"}, nil, nil); !IsDuplicatedKeyError(err) {
+		t.Errorf("Expected ErrDuplicatedKey without conflict columns, got %v", err)
+	}
+
+	var items []*TestUpsertItem
+	GetDBWithContext(ctx).Find(&items)
+	if len(items) != 1 || items[0].Name != "b" {
+		t.Errorf("Expected a single upserted record named b, got %+v", items)
+	}
+
+	t.Run("FindOneOrCreate Race", func(t *testing.T) {
+		one, err := FindOneOrCreateCtx[TestUpsertItem](ctx, map[any]any{"email": "race@x.com"}, func(one *TestUpsertItem) {
+			// another request creates the record between the find and the create
+			GetDBWithContext(ctx).Create(&TestUpsertItem{Email: "race@x.com", Name: "winner"})
+
+			one.Email = "race@x.com"
+			one.Name = "loser"
+		})
+		if err != nil {
+			t.Fatalf("FindOneOrCreate failed: %v", err)
+		}
+
+		if one.Name != "winner" {
+			t.Errorf("Expected the concurrently created record, got %+v", one)
+		}
+
+		var count int64
+		GetDBWithContext(ctx).Model(&TestUpsertItem{}).Where("email = ?", "race@x.com").Count(&count)
+		if count != 1 {
+			t.Errorf("Expected 1 record, got %d", count)
+		}
+	})
+
+	t.Run("Translate Error", func(t *testing.T) {
+		d, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		if err != nil {
+			t.Fatalf("gorm.Open failed: %v", err)
+		}
+		SetNamedDB("test_upsert_set", d)
+
+		ctx := WithConnection(context.Background(), "test_upsert_set")
+		if err := GetDBWithContext(ctx).AutoMigrate(&TestUpsertItem{}); err != nil {
+			t.Fatalf("AutoMigrate failed: %v", err)
+		}
+
+		GetDBWithContext(ctx).Create(&TestUpsertItem{Email: "dup@x.com"})
+		err = GetDBWithContext(ctx).Create(&TestUpsertItem{Email: "dup@x.com"}).Error
+		if err == nil || IsDuplicatedKeyError(err) {
+			t.Fatalf("Expected the untranslated driver error, got %v", err)
+		}
+
+		if !IsDuplicatedKeyError(translateError(GetDBWithContext(ctx), err)) {
+			t.Errorf("Expected ErrDuplicatedKey, got %v", translateError(GetDBWithContext(ctx), err))
+		}
+	})
+}
@@ -2,7 +2,10 @@ package gormx
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
+
+	"gorm.io/gorm"
 )
 
 // WhereCondition is a type constraint for where conditions.
@@ -58,9 +61,41 @@ type WhereOne struct {
 	// IsPlain => plain
 	IsPlain bool
 
+	// IsGreaterThan => >
+	IsGreaterThan bool
+	// IsGreaterOrEqual => >=
+	IsGreaterOrEqual bool
+	// IsLessThan => <
+	IsLessThan bool
+	// IsLessOrEqual => <=
+	IsLessOrEqual bool
+	// IsBetween => BETWEEN ? AND ?, value is [start, end]
+	IsBetween bool
+
+	// IsNull => IS NULL
+	IsNull bool
+	// IsNotNull => IS NOT NULL
+	IsNotNull bool
+
+	// IsStartsWith => ILike 'value%'
+	IsStartsWith bool
+	// IsEndsWith => ILike '%value'
+	IsEndsWith bool
+
 	// IsFullTextSearch => ILike (field1) OR ILike (field2) OR ...
 	IsFullTextSearch     bool
 	FullTextSearchFields []string
+
+	// IsOr => joins with the previous conditions by OR instead of AND
+	IsOr bool
+	// IsNot => NOT (...)
+	IsNot bool
+
+	// Group => (nested conditions)
+	Group *Where
+
+	// IsQuoted => the key is a column name quoted by the dialect
+	IsQuoted bool
 }
 
 // Where is the where.
@@ -68,6 +103,14 @@ type Where struct {
 	Items []WhereOne
 	//
 	FullTextSearchFields []string
+
+	// Dialect is the database dialect (postgres, mysql, sqlite) used to build
+	// case-insensitive matching, default is the engine of the default connection.
+	Dialect string
+
+	// FullTextSearch is the full text search strategy of the q keyword,
+	// default is the one of the model (FullTextSearchModel), or LikeFullTextSearch.
+	FullTextSearch FullTextSearch
 }
 
 // SetWhereOptions is the options for SetWhere.
@@ -78,8 +121,19 @@ type SetWhereOptions struct {
 	IsIn                 bool
 	IsNotIn              bool
 	IsPlain              bool
+	IsGreaterThan        bool
+	IsGreaterOrEqual     bool
+	IsLessThan           bool
+	IsLessOrEqual        bool
+	IsBetween            bool
+	IsNull               bool
+	IsNotNull            bool
+	IsStartsWith         bool
+	IsEndsWith           bool
 	IsFullTextSearch     bool
 	FullTextSearchFields []string
+	IsOr                 bool
+	IsNot                bool
 }
 
 // NewWhere returns a new where.
@@ -117,13 +171,55 @@ func (w *Where) Add(key string, value interface{}, opts ...*SetWhereOptions) {
 		item.IsIn = opt.IsIn
 		item.IsNotIn = opt.IsNotIn
 		item.IsPlain = opt.IsPlain
+		item.IsGreaterThan = opt.IsGreaterThan
+		item.IsGreaterOrEqual = opt.IsGreaterOrEqual
+		item.IsLessThan = opt.IsLessThan
+		item.IsLessOrEqual = opt.IsLessOrEqual
+		item.IsBetween = opt.IsBetween
+		item.IsNull = opt.IsNull
+		item.IsNotNull = opt.IsNotNull
+		item.IsStartsWith = opt.IsStartsWith
+		item.IsEndsWith = opt.IsEndsWith
 		item.IsFullTextSearch = opt.IsFullTextSearch
 		item.FullTextSearchFields = opt.FullTextSearchFields
+		item.IsOr = opt.IsOr
+		item.IsNot = opt.IsNot
 	}
 
 	w.Items = append(w.Items, item)
 }
 
+// AddRaw adds a raw sql fragment with its args, built as (sql).
+func (w *Where) AddRaw(sql string, args ...interface{}) {
+	w.Add(sql, args, &SetWhereOptions{IsPlain: true})
+}
+
+// And adds a nested group joined by AND, built as (sub).
+func (w *Where) And(sub *Where) *Where {
+	w.Items = append(w.Items, WhereOne{
+		Group: sub,
+	})
+	return w
+}
+
+// Or adds a nested group joined by OR, built as OR (sub).
+func (w *Where) Or(sub *Where) *Where {
+	w.Items = append(w.Items, WhereOne{
+		Group: sub,
+		IsOr:  true,
+	})
+	return w
+}
+
+// Not adds a negated nested group joined by AND, built as NOT (sub).
+func (w *Where) Not(sub *Where) *Where {
+	w.Items = append(w.Items, WhereOne{
+		Group: sub,
+		IsNot: true,
+	})
+	return w
+}
+
 // Get gets a where.
 func (w *Where) Get(key string) (interface{}, bool) {
 	for _, v := range w.Items {
@@ -150,90 +246,269 @@ func (w *Where) Length() int {
 	return len(w.Items)
 }
 
+// BuildFor builds the wheres with the dialect of the given db.
+func (w *Where) BuildFor(db *gorm.DB) (query string, args []interface{}, err error) {
+	if db == nil || db.Dialector == nil {
+		return w.Build()
+	}
+
+	return w.bindTo(db).Build()
+}
+
+// bindTo returns a copy of the wheres with the dialect and the model
+// full text search strategy of the given db.
+func (w *Where) bindTo(db *gorm.DB) *Where {
+	c := *w
+	c.Dialect = db.Dialector.Name()
+
+	if c.FullTextSearch == nil && db.Statement != nil {
+		if m, ok := db.Statement.Model.(FullTextSearchModel); ok {
+			c.FullTextSearch = m.FullTextSearch()
+		}
+	}
+
+	return &c
+}
+
+// BuildRankFor builds the relevance score of the full text search with the dialect of the given db.
+func (w *Where) BuildRankFor(db *gorm.DB) (expr string, args []interface{}, err error) {
+	if db == nil || db.Dialector == nil {
+		return w.BuildRank()
+	}
+
+	return w.bindTo(db).BuildRank()
+}
+
+// BuildRank builds the relevance score of the full text search, higher is better.
+func (w *Where) BuildRank() (expr string, args []interface{}, err error) {
+	for _, item := range w.Items {
+		if item.Key != "q" && !item.IsFullTextSearch {
+			continue
+		}
+
+		fields := item.FullTextSearchFields
+		if len(fields) == 0 {
+			fields = w.FullTextSearchFields
+		}
+
+		keyword, ok := item.Value.(string)
+		if !ok || len(fields) == 0 {
+			continue
+		}
+
+		return w.getFullTextSearch().Rank(w.getDialect(), fields, keyword)
+	}
+
+	return "", nil, fmt.Errorf("no full text search to rank")
+}
+
 // Build builds the wheres.
 func (w *Where) Build() (query string, args []interface{}, err error) {
-	whereClauses := []string{}
+	whereClause := ""
 	whereValues := []interface{}{}
+	lastJoiner := ""
 	for _, item := range w.Items {
-		// @TODO built-in keywords
-		if item.Key == "q" {
-			item.IsFullTextSearch = true
-			item.FullTextSearchFields = w.FullTextSearchFields
+		clause, values, err := w.buildOne(item)
+		if err != nil {
+			return "", nil, err
 		}
 
-		// @TODO full text search search keyword
-		if item.IsFullTextSearch {
-			// ignore if no fields
-			if len(item.FullTextSearchFields) == 0 {
-				// return "", nil, fmt.Errorf("FullTextSearchFields is required when IsFullTextSearch is true (key: %s)", item.Key)
-				// continue
-				if len(w.FullTextSearchFields) == 0 {
-					// return "", nil, fmt.Errorf("FullTextSearchFields is required when IsFullTextSearch is true (key: %s)", item.Key)
-					continue
-				}
+		// ignore empty conditions, e.g. full text search without fields
+		if clause == "" {
+			continue
+		}
 
-				item.FullTextSearchFields = w.FullTextSearchFields
+		if item.IsNot {
+			if item.Group != nil {
+				// groups are parenthesised already
+				clause = fmt.Sprintf("NOT %s", clause)
+			} else {
+				clause = fmt.Sprintf("NOT (%s)", clause)
 			}
+		}
 
-			keyword, v := item.Value.(string)
-			if !v {
-				return "", nil, fmt.Errorf("value must be string when IsFullTextSearch is true (key: %s)", item.Key)
-			}
+		whereValues = append(whereValues, values...)
+		if whereClause == "" {
+			whereClause = clause
+			continue
+		}
 
-			// @TODO
-			keywordExtract := strings.Replace(keyword, ":*", "", 1)
+		joiner := "AND"
+		if item.IsOr {
+			joiner = "OR"
+		}
 
-			//
-			keywordFuzzy := fmt.Sprintf("%%%s%%", keywordExtract)
-			qs := []string{}
-			args := []interface{}{}
+		// conditions are joined from left to right,
+		// so wrap the previous ones when the joiner changes
+		if lastJoiner != "" && lastJoiner != joiner {
+			whereClause = fmt.Sprintf("(%s)", whereClause)
+		}
 
-			fields := item.FullTextSearchFields
-			for _, field := range fields {
-				qs = append(qs, fmt.Sprintf("%s ILike ?", field))
-				args = append(args, keywordFuzzy)
-			}
-			query := strings.Join(qs, " OR ")
+		whereClause = fmt.Sprintf("%s %s %s", whereClause, joiner, clause)
+		lastJoiner = joiner
+	}
 
-			whereClauses = append(whereClauses, fmt.Sprintf("(%s)", query))
-			whereValues = append(whereValues, args...)
-		} else {
-			if item.IsFuzzy {
-				whereClauses = append(whereClauses, fmt.Sprintf("%s ILike ?", item.Key))
-				whereValues = append(whereValues, fmt.Sprintf("%%%s%%", item.Value))
-			} else if item.IsEqual {
-				whereClauses = append(whereClauses, fmt.Sprintf("%s = ?", item.Key))
-				whereValues = append(whereValues, item.Value)
-			} else if item.IsNotEqual {
-				whereClauses = append(whereClauses, fmt.Sprintf("%s != ?", item.Key))
-				whereValues = append(whereValues, item.Value)
-			} else if item.IsIn {
-				whereClauses = append(whereClauses, fmt.Sprintf("%s in (?)", item.Key))
-				whereValues = append(whereValues, item.Value)
-			} else if item.IsNotIn {
-				whereClauses = append(whereClauses, fmt.Sprintf("%s not in (?)", item.Key))
-				whereValues = append(whereValues, item.Value)
-			} else if item.IsPlain {
-				whereClauses = append(whereClauses, fmt.Sprintf("(%s)", item.Key))
-				if v, ok := item.Value.([]any); ok {
-					whereValues = append(whereValues, v...)
-				} else {
-					whereValues = append(whereValues, item.Value)
-				}
-			} else {
-				whereClauses = append(whereClauses, fmt.Sprintf("%s = ?", item.Key))
-				whereValues = append(whereValues, item.Value)
+	return whereClause, whereValues, nil
+}
+
+func (w *Where) buildOne(item WhereOne) (clause string, values []interface{}, err error) {
+	if item.Group != nil {
+		sub := *item.Group
+		if len(sub.FullTextSearchFields) == 0 {
+			sub.FullTextSearchFields = w.FullTextSearchFields
+		}
+		if sub.Dialect == "" {
+			sub.Dialect = w.Dialect
+		}
+		if sub.FullTextSearch == nil {
+			sub.FullTextSearch = w.FullTextSearch
+		}
+
+		query, args, err := sub.Build()
+		if err != nil || query == "" {
+			return "", nil, err
+		}
+
+		return fmt.Sprintf("(%s)", query), args, nil
+	}
+
+	// @TODO built-in keywords
+	if item.Key == "q" {
+		item.IsFullTextSearch = true
+		item.FullTextSearchFields = w.FullTextSearchFields
+	}
+
+	// @TODO full text search search keyword
+	if item.IsFullTextSearch {
+		// ignore if no fields
+		if len(item.FullTextSearchFields) == 0 {
+			// return "", nil, fmt.Errorf("FullTextSearchFields is required when IsFullTextSearch is true (key: %s)", item.Key)
+			// continue
+			if len(w.FullTextSearchFields) == 0 {
+				// return "", nil, fmt.Errorf("FullTextSearchFields is required when IsFullTextSearch is true (key: %s)", item.Key)
+				return "", nil, nil
 			}
+
+			item.FullTextSearchFields = w.FullTextSearchFields
+		}
+
+		keyword, v := item.Value.(string)
+		if !v {
+			return "", nil, fmt.Errorf("value must be string when IsFullTextSearch is true (key: %s)", item.Key)
 		}
+
+		return w.getFullTextSearch().Build(w.getDialect(), item.FullTextSearchFields, keyword)
 	}
-	whereClause := strings.Join(whereClauses, " AND ")
 
-	return whereClause, whereValues, nil
+	column := w.column(item)
+	if item.IsFuzzy {
+		return w.buildLike(column), []interface{}{fmt.Sprintf("%%%s%%", escapeLike(fmt.Sprint(item.Value)))}, nil
+	} else if item.IsEqual {
+		return fmt.Sprintf("%s = ?", column), []interface{}{item.Value}, nil
+	} else if item.IsNotEqual {
+		return fmt.Sprintf("%s != ?", column), []interface{}{item.Value}, nil
+	} else if item.IsIn {
+		return fmt.Sprintf("%s in (?)", column), []interface{}{item.Value}, nil
+	} else if item.IsNotIn {
+		return fmt.Sprintf("%s not in (?)", column), []interface{}{item.Value}, nil
+	} else if item.IsGreaterThan {
+		return fmt.Sprintf("%s > ?", column), []interface{}{item.Value}, nil
+	} else if item.IsGreaterOrEqual {
+		return fmt.Sprintf("%s >= ?", column), []interface{}{item.Value}, nil
+	} else if item.IsLessThan {
+		return fmt.Sprintf("%s < ?", column), []interface{}{item.Value}, nil
+	} else if item.IsLessOrEqual {
+		return fmt.Sprintf("%s <= ?", column), []interface{}{item.Value}, nil
+	} else if item.IsBetween {
+		rv := reflect.ValueOf(item.Value)
+		if (rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array) || rv.Len() != 2 {
+			return "", nil, fmt.Errorf("value must be [start, end] when IsBetween is true (key: %s)", item.Key)
+		}
+
+		return fmt.Sprintf("%s BETWEEN ? AND ?", column), []interface{}{rv.Index(0).Interface(), rv.Index(1).Interface()}, nil
+	} else if item.IsNull {
+		return fmt.Sprintf("%s IS NULL", column), nil, nil
+	} else if item.IsNotNull {
+		return fmt.Sprintf("%s IS NOT NULL", column), nil, nil
+	} else if item.IsStartsWith {
+		return w.buildLike(column), []interface{}{fmt.Sprintf("%s%%", escapeLike(fmt.Sprint(item.Value)))}, nil
+	} else if item.IsEndsWith {
+		return w.buildLike(column), []interface{}{fmt.Sprintf("%%%s", escapeLike(fmt.Sprint(item.Value)))}, nil
+	} else if item.IsPlain {
+		if v, ok := item.Value.([]any); ok {
+			return fmt.Sprintf("(%s)", item.Key), v, nil
+		}
+
+		return fmt.Sprintf("(%s)", item.Key), []interface{}{item.Value}, nil
+	}
+
+	return fmt.Sprintf("%s = ?", column), []interface{}{item.Value}, nil
+}
+
+func (w *Where) getDialect() string {
+	if w.Dialect != "" {
+		return w.Dialect
+	}
+
+	return GetEngine()
+}
+
+// column returns the column of the where one, quoted if IsQuoted.
+func (w *Where) column(item WhereOne) string {
+	if !item.IsQuoted {
+		return item.Key
+	}
+
+	return quoteIdentifier(w.getDialect(), item.Key)
+}
+
+func (w *Where) getFullTextSearch() FullTextSearch {
+	if w.FullTextSearch != nil {
+		return w.FullTextSearch
+	}
+
+	return &LikeFullTextSearch{}
+}
+
+// buildLike builds the case-insensitive LIKE of the dialect,
+// the pattern is escaped by escapeLike.
+func (w *Where) buildLike(field string) string {
+	switch w.getDialect() {
+	case "postgres":
+		// backslash is the default escape character of postgres
+		return fmt.Sprintf("%s ILIKE ?", field)
+	case "sqlite":
+		return fmt.Sprintf("%s COLLATE NOCASE LIKE ? ESCAPE '\\'", field)
+	default:
+		// backslash is the default escape character of mysql
+		return fmt.Sprintf("LOWER(%s) LIKE LOWER(?)", field)
+	}
+}
+
+// escapeLike escapes the LIKE wildcards in user input.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+var likeEscaper = strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_")
+
+// isSimpleEqual returns true if the where one is a plain equality condition.
+func (item *WhereOne) isSimpleEqual() bool {
+	return !(item.IsFuzzy || item.IsIn || item.IsNotIn || item.IsPlain || item.IsFullTextSearch || item.IsNotEqual ||
+		item.IsGreaterThan || item.IsGreaterOrEqual || item.IsLessThan || item.IsLessOrEqual || item.IsBetween ||
+		item.IsNull || item.IsNotNull || item.IsStartsWith || item.IsEndsWith ||
+		item.IsOr || item.IsNot || item.Group != nil)
 }
 
 // Debug prints the wheres.
 func (w *Where) Debug() {
 	for _, item := range w.Items {
+		if item.Group != nil {
+			fmt.Printf("[where] group (or: %v, not: %v)\n", item.IsOr, item.IsNot)
+			item.Group.Debug()
+			continue
+		}
+
 		var fuzzy string
 		if item.IsFuzzy {
 			fuzzy = "Fuzzy"
@@ -249,3 +524,24 @@ func (w *Where) Debug() {
 func (w *Where) Reset() {
 	w.Items = []WhereOne{}
 }
+
+// Clone returns a deep copy of the wheres, including the nested groups.
+func (w *Where) Clone() *Where {
+	if w == nil {
+		return nil
+	}
+
+	c := *w
+	c.FullTextSearchFields = append([]string(nil), w.FullTextSearchFields...)
+
+	if w.Items != nil {
+		c.Items = make([]WhereOne, len(w.Items))
+		for i, item := range w.Items {
+			item.FullTextSearchFields = append([]string(nil), item.FullTextSearchFields...)
+			item.Group = item.Group.Clone()
+			c.Items[i] = item
+		}
+	}
+
+	return &c
+}
@@ -0,0 +1,197 @@
+package gormx
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWhere_Build(t *testing.T) {
+	t.Run("AND", func(t *testing.T) {
+		where := NewWhere()
+		where.Set("a", 1)
+		where.Set("b", 2, &SetWhereOptions{IsNotEqual: true})
+
+		query, args, err := where.Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+
+		assertWhere(t, query, args, "a = ? AND b != ?", []interface{}{1, 2})
+	})
+
+	t.Run("OR Group", func(t *testing.T) {
+		sub := NewWhere()
+		sub.Set("b", 2)
+		sub.Set("c", 3)
+
+		where := NewWhere()
+		where.Set("a", 1)
+		where.Or(sub)
+
+		query, args, err := where.Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+
+		assertWhere(t, query, args, "a = ? OR (b = ? AND c = ?)", []interface{}{1, 2, 3})
+	})
+
+	t.Run("NOT Group", func(t *testing.T) {
+		sub := NewWhere()
+		sub.Set("b", 2)
+
+		where := NewWhere()
+		where.Set("a", 1)
+		where.Not(sub)
+
+		query, args, err := where.Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+
+		assertWhere(t, query, args, "a = ? AND NOT (b = ?)", []interface{}{1, 2})
+	})
+
+	t.Run("Mixed Joiners", func(t *testing.T) {
+		where := NewWhere()
+		where.Set("a", 1)
+		where.Set("b", 2)
+		where.Add("c", 3, &SetWhereOptions{IsOr: true})
+		where.Set("d", 4)
+
+		query, args, err := where.Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+
+		assertWhere(t, query, args, "((a = ? AND b = ?) OR c = ?) AND d = ?", []interface{}{1, 2, 3, 4})
+	})
+
+	t.Run("Comparison Operators", func(t *testing.T) {
+		where := NewWhere()
+		where.Add("age", 18, &SetWhereOptions{IsGreaterOrEqual: true})
+		where.Add("age", 65, &SetWhereOptions{IsLessThan: true})
+		where.Add("score", 1, &SetWhereOptions{IsGreaterThan: true})
+		where.Add("rank", 9, &SetWhereOptions{IsLessOrEqual: true})
+
+		query, args, err := where.Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+
+		assertWhere(t, query, args, "age >= ? AND age < ? AND score > ? AND rank <= ?", []interface{}{18, 65, 1, 9})
+	})
+
+	t.Run("Between And Null", func(t *testing.T) {
+		where := NewWhere()
+		where.Set("price", []int{10, 20}, &SetWhereOptions{IsBetween: true})
+		where.Set("deleted_at", nil, &SetWhereOptions{IsNull: true})
+		where.Set("name", nil, &SetWhereOptions{IsNotNull: true})
+
+		query, args, err := where.Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+
+		assertWhere(t, query, args, "price BETWEEN ? AND ? AND deleted_at IS NULL AND name IS NOT NULL", []interface{}{10, 20})
+	})
+
+	t.Run("Between Invalid Value", func(t *testing.T) {
+		where := NewWhere()
+		where.Set("price", 10, &SetWhereOptions{IsBetween: true})
+
+		if _, _, err := where.Build(); err == nil {
+			t.Error("Expected error for non [start, end] value")
+		}
+	})
+
+	t.Run("Raw", func(t *testing.T) {
+		where := NewWhere()
+		where.Set("a", 1)
+		where.AddRaw("b > ? OR c < ?", 2, 3)
+		where.AddRaw("d IS NOT NULL")
+		where.Set("e", 4)
+
+		query, args, err := where.Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+
+		assertWhere(t, query, args, "a = ? AND (b > ? OR c < ?) AND (d IS NOT NULL) AND e = ?", []interface{}{1, 2, 3, 4})
+	})
+
+	t.Run("Empty Group", func(t *testing.T) {
+		where := NewWhere()
+		where.Set("a", 1)
+		where.Or(NewWhere())
+
+		query, args, err := where.Build()
+		if err != nil {
+			t.Fatalf("Build failed: %v", err)
+		}
+
+		assertWhere(t, query, args, "a = ?", []interface{}{1})
+	})
+}
+
+func assertWhere(t *testing.T, query string, args []interface{}, expectedQuery string, expectedArgs []interface{}) {
+	t.Helper()
+
+	if query != expectedQuery {
+		t.Errorf("Expected query %q, got %q", expectedQuery, query)
+	}
+
+	if !reflect.DeepEqual(args, expectedArgs) {
+		t.Errorf("Expected args %v, got %v", expectedArgs, args)
+	}
+}
+
+func TestWhere_BuildLike(t *testing.T) {
+	cases := []struct {
+		dialect string
+		query   string
+	}{
+		{"postgres", "name ILIKE ?"},
+		{"sqlite", "name COLLATE NOCASE LIKE ? ESCAPE '\\'"},
+		{"mysql", "LOWER(name) LIKE LOWER(?)"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.dialect, func(t *testing.T) {
+			where := NewWhere()
+			where.Dialect = c.dialect
+			where.Set("name", "50%_off", &SetWhereOptions{IsFuzzy: true})
+
+			query, args, err := where.Build()
+			if err != nil {
+				t.Fatalf("Build failed: %v", err)
+			}
+
+			assertWhere(t, query, args, c.query, []interface{}{"%50\\%\\_off%"})
+		})
+	}
+}
+
+type TestLikeItem struct {
+	ID   uint   `gorm:"primarykey"`
+	Name string `gorm:"column:name"`
+}
+
+func TestWhere_BuildLikeSQLite(t *testing.T) {
+	ctx := openTestDB(t, "test_like", &TestLikeItem{})
+	d := GetNamedDB("test_like")
+	d.Create(&[]TestLikeItem{{Name: "Big_Sale"}, {Name: "bigXsale"}, {Name: "other"}})
+
+	where := NewWhere()
+	where.Set("name", "big_sale", &SetWhereOptions{IsFuzzy: true})
+
+	count, err := CountCtx[TestLikeItem](ctx, where)
+	if err != nil {
+		t.Fatalf("CountCtx failed: %v", err)
+	}
+
+	// case-insensitive, and _ matches itself only
+	if count != 1 {
+		t.Errorf("Expected 1 result, got %d", count)
+	}
+}
@@ -0,0 +1,181 @@
+package gormx
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// FilterableModel is implemented by the models that declare the columns
+// allowed in the query string filters, default is every column of the schema.
+type FilterableModel interface {
+	FilterableFields() []string
+}
+
+// SortableModel is implemented by the models that declare the columns
+// allowed in the query string order by, default is every column of the schema.
+type SortableModel interface {
+	SortableFields() []string
+}
+
+// identifierPattern matches column and table.column names.
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// IsValidIdentifier returns true if name is a plain column or table.column name.
+func IsValidIdentifier(name string) bool {
+	return identifierPattern.MatchString(name)
+}
+
+// quoteIdentifier quotes the column or table.column name by the dialect.
+func quoteIdentifier(dialect string, name string) string {
+	quote := `"`
+	if dialect == "mysql" {
+		quote = "`"
+	}
+
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = quote + strings.ReplaceAll(part, quote, quote+quote) + quote
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// fieldWhitelist is the columns allowed in the query string filters and order by.
+type fieldWhitelist struct {
+	filterable map[string]bool
+	sortable   map[string]bool
+}
+
+// newFieldWhitelist returns the whitelist of the model,
+// from FilterableFields/SortableFields or the gorm schema.
+func newFieldWhitelist(model any) (*fieldWhitelist, error) {
+	var columns []string
+	columnsOf := func() ([]string, error) {
+		if columns != nil {
+			return columns, nil
+		}
+
+		s, err := ParseSchema(model)
+		if err != nil {
+			return nil, fmt.Errorf("parse model schema failed: %s", err)
+		}
+
+		columns = []string{}
+		for _, field := range s.Fields {
+			if field.DBName != "" && field.Readable {
+				columns = append(columns, field.DBName)
+			}
+		}
+
+		return columns, nil
+	}
+
+	whitelist := &fieldWhitelist{
+		filterable: map[string]bool{},
+		sortable:   map[string]bool{},
+	}
+
+	var filterable []string
+	if m, ok := model.(FilterableModel); ok {
+		filterable = m.FilterableFields()
+	} else {
+		cs, err := columnsOf()
+		if err != nil {
+			return nil, err
+		}
+		filterable = cs
+	}
+
+	var sortable []string
+	if m, ok := model.(SortableModel); ok {
+		sortable = m.SortableFields()
+	} else {
+		cs, err := columnsOf()
+		if err != nil {
+			return nil, err
+		}
+		sortable = cs
+	}
+
+	for _, field := range filterable {
+		whitelist.filterable[field] = true
+	}
+	for _, field := range sortable {
+		whitelist.sortable[field] = true
+	}
+
+	return whitelist, nil
+}
+
+// override returns a copy of the whitelist with the filterable and sortable fields if not nil,
+// a nil whitelist allows no other fields.
+func (wl *fieldWhitelist) override(filterable, sortable []string) *fieldWhitelist {
+	c := &fieldWhitelist{
+		filterable: map[string]bool{},
+		sortable:   map[string]bool{},
+	}
+	if wl != nil {
+		c.filterable, c.sortable = wl.filterable, wl.sortable
+	}
+
+	if filterable != nil {
+		c.filterable = map[string]bool{}
+		for _, field := range filterable {
+			c.filterable[field] = true
+		}
+	}
+	if sortable != nil {
+		c.sortable = map[string]bool{}
+		for _, field := range sortable {
+			c.sortable[field] = true
+		}
+	}
+
+	return c
+}
+
+// guardWhere checks the keys of the query string filters, and marks them as quoted columns.
+func (wl *fieldWhitelist) guardWhere(where *Where) error {
+	for i := range where.Items {
+		item := &where.Items[i]
+		if item.Group != nil {
+			if err := wl.guardWhere(item.Group); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		// built-in full text search keyword
+		if item.Key == "q" {
+			continue
+		}
+
+		if !IsValidIdentifier(item.Key) || (wl != nil && !wl.filterable[item.Key]) {
+			return &UnknownFieldError{Field: item.Key, Usage: "filter"}
+		}
+
+		item.IsQuoted = true
+	}
+
+	return nil
+}
+
+// guardOrderBy checks the keys of the query string order by, and marks them as quoted columns.
+func (wl *fieldWhitelist) guardOrderBy(orderBy *OrderBy) error {
+	for i := range *orderBy {
+		order := &(*orderBy)[i]
+		if order.Key == RankOrderKey {
+			continue
+		}
+
+		if !IsValidIdentifier(order.Key) || (wl != nil && !wl.sortable[order.Key]) {
+			return &UnknownFieldError{Field: order.Key, Usage: "sort"}
+		}
+
+		order.IsQuoted = true
+	}
+
+	return nil
+}